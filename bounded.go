@@ -0,0 +1,37 @@
+package snapset
+
+// NewBounded creates and returns a new instance of Set that never holds more than maxSize elements.
+// When Insert would add an element to a full set, a random existing element is evicted first,
+// which makes the set usable as a fixed-memory sampling cache with random admission.
+// onEvict, if not nil, is called with each evicted element after it has been removed;
+// it runs synchronously and must not modify the set. NewBounded panics if maxSize is not positive.
+func NewBounded[T comparable](maxSize int, onEvict func(T)) SnapSet[T] {
+	if maxSize <= 0 {
+		panic("snapset: non-positive bound")
+	}
+	s := newSet[T](maxSize, newSource())
+	s.maxSize = maxSize
+	s.onEvict = onEvict
+	return s
+}
+
+// full reports whether the set is bounded and has reached its bound.
+func (s *Set[T]) full() bool {
+	return s.maxSize > 0 && len(s.list) >= s.maxSize
+}
+
+// evict removes one element to make room for an insertion and reports it to onEvict.
+// The element is chosen by the victim policy if one is set, or at random otherwise.
+func (s *Set[T]) evict() {
+	var idx int
+	if s.victim != nil {
+		idx = s.victim()
+	} else {
+		idx = s.rand.Intn(len(s.list))
+	}
+
+	element := s.removeAt(idx)
+	if s.onEvict != nil {
+		s.onEvict(element)
+	}
+}
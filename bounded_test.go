@@ -0,0 +1,70 @@
+package snapset_test
+
+import (
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestNewBounded checks that a bounded set evicts to stay within its bound.
+func TestNewBounded(t *testing.T) {
+	var evicted []int
+	s := snapset.NewBounded(3, func(val int) { evicted = append(evicted, val) })
+
+	// Fill the set to its bound
+	s.InsertMany(1, 2, 3)
+	if len(evicted) != 0 {
+		t.Errorf("Expected no evictions, got %v", evicted)
+	}
+
+	// Re-inserting an existing element does not evict
+	s.Insert(2)
+	if len(evicted) != 0 {
+		t.Errorf("Expected no evictions, got %v", evicted)
+	}
+
+	// Inserting a new element evicts one of the old ones
+	idx := s.Insert(4)
+	if s.Len() != 3 || !s.Exists(4) {
+		t.Errorf("Expected length 3 including 4, got %v", s)
+	}
+	if val, _ := s.At(idx); val != 4 {
+		t.Errorf("Expected index %d to hold 4, got %d", idx, val)
+	}
+	if len(evicted) != 1 || s.Exists(evicted[0]) {
+		t.Errorf("Expected one evicted element no longer in the set, got %v", evicted)
+	}
+
+	// The bound is kept by clones
+	c := s.Clone()
+	c.Insert(5)
+	if c.Len() != 3 {
+		t.Errorf("Expected clone length 3, got %d", c.Len())
+	}
+}
+
+// TestBoundedCapacity checks that a bounded set never grows its backing slice past its bound.
+func TestBoundedCapacity(t *testing.T) {
+	s := snapset.NewBounded[int](4, nil)
+
+	data := make([]int, 100000)
+	for i := range data {
+		data[i] = i
+	}
+	s.InsertMany(data...)
+	if s.Len() != 4 || s.Cap() != 4 {
+		t.Errorf("Expected length and capacity 4, got %d and %d", s.Len(), s.Cap())
+	}
+
+	s.Grow(1000)
+	if s.Cap() != 4 {
+		t.Errorf("Expected Grow to stop at the bound, got capacity %d", s.Cap())
+	}
+
+	// A clone starts small and is clamped as it grows
+	c := s.Clone()
+	c.InsertMany(data...)
+	if c.Cap() != 4 {
+		t.Errorf("Expected clone capacity 4, got %d", c.Cap())
+	}
+}
@@ -0,0 +1,630 @@
+package snapset
+
+import (
+	"context"
+	"iter"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// ConcurrentSet is a SnapSet that is safe for concurrent use by multiple goroutines.
+// It wraps a Set and guards it with a sync.RWMutex: read-only operations hold the read lock,
+// while operations that modify the set hold the write lock.
+type ConcurrentSet[T comparable] struct {
+	mu     sync.RWMutex
+	randMu sync.Mutex // serializes the random number generator, which is shared by readers
+	set    *Set[T]
+}
+
+// NewConcurrent creates and returns a new concurrency-safe set with the specified initial size.
+// It satisfies the SnapSet interface, so it can be used wherever a set from New is expected.
+func NewConcurrent[T comparable](size int) SnapSet[T] {
+	return &ConcurrentSet[T]{set: newSet[T](size, newSource())}
+}
+
+// Insert adds the specified element to the set under the write lock.
+func (c *ConcurrentSet[T]) Insert(data T) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.Insert(data)
+}
+
+// Delete removes the specified element from the set under the write lock.
+func (c *ConcurrentSet[T]) Delete(element T) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.Delete(element)
+}
+
+// Exists checks whether the specified element exists in the set under the read lock.
+func (c *ConcurrentSet[T]) Exists(element T) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Exists(element)
+}
+
+// GetRandom returns a random element from the set under the read lock.
+// Like Set.GetRandom, it panics if the set is empty.
+func (c *ConcurrentSet[T]) GetRandom() T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.randMu.Lock()
+	defer c.randMu.Unlock()
+	return c.set.GetRandom()
+}
+
+// MustGetRandom returns a random element under the read lock,
+// panicking with a descriptive message if the set is empty.
+func (c *ConcurrentSet[T]) MustGetRandom() T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.randMu.Lock()
+	defer c.randMu.Unlock()
+	return c.set.MustGetRandom()
+}
+
+// GetRandomOK returns a random element from the set and true under the read lock,
+// or the zero value and false if the set is empty.
+func (c *ConcurrentSet[T]) GetRandomOK() (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.randMu.Lock()
+	defer c.randMu.Unlock()
+	return c.set.GetRandomOK()
+}
+
+// Len returns the number of elements in the set under the read lock.
+func (c *ConcurrentSet[T]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Len()
+}
+
+// Clear removes all elements from the set under the write lock.
+func (c *ConcurrentSet[T]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.Clear()
+}
+
+// ToSlice returns a copy of all elements in the set under the read lock.
+func (c *ConcurrentSet[T]) ToSlice() []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.ToSlice()
+}
+
+// Clone returns an independent, concurrency-safe deep copy of the set.
+func (c *ConcurrentSet[T]) Clone() SnapSet[T] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return &ConcurrentSet[T]{set: c.set.Clone().(*Set[T])}
+}
+
+// CloneCOW returns an eager deep copy of the set, like Clone.
+// Sharing storage between sets guarded by different locks would not be safe,
+// so the concurrent variant never defers the copy.
+func (c *ConcurrentSet[T]) CloneCOW() SnapSet[T] {
+	return c.Clone()
+}
+
+// IsSubset reports whether every element of the set is also present in other, under the read lock.
+// If other is itself a ConcurrentSet, the smaller of the two sets is copied and the check runs
+// under the other one's read lock, so it sees a single consistent state of each set.
+func (c *ConcurrentSet[T]) IsSubset(other SnapSet[T]) bool {
+	if o, ok := c.larger(other); ok {
+		return o.IsSuperset(c.plain())
+	}
+	other = detach(other)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.IsSubset(other)
+}
+
+// IsSuperset reports whether every element of other is also present in the set.
+// The elements of other are copied before this set's read lock is acquired.
+func (c *ConcurrentSet[T]) IsSuperset(other SnapSet[T]) bool {
+	elements := other.ToSlice()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(elements) > c.set.Len() {
+		return false
+	}
+
+	for _, element := range elements {
+		if !c.set.Exists(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether the set and other contain exactly the same elements, under the read lock.
+// If other is itself a ConcurrentSet, the smaller of the two sets is copied first.
+func (c *ConcurrentSet[T]) Equal(other SnapSet[T]) bool {
+	if o, ok := c.larger(other); ok {
+		return o.Equal(c.plain())
+	}
+	other = detach(other)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Equal(other)
+}
+
+// Pop removes and returns a random element from the set under the write lock.
+func (c *ConcurrentSet[T]) Pop() (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.Pop()
+}
+
+// InsertMany adds all given elements to the set under a single write lock.
+func (c *ConcurrentSet[T]) InsertMany(data ...T) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.InsertMany(data...)
+}
+
+// InsertFromChan inserts every value received from ch until ch is closed.
+// The write lock is taken per value rather than for the whole drain,
+// so a producer can feed the set while other goroutines keep using it.
+func (c *ConcurrentSet[T]) InsertFromChan(ch <-chan T) int {
+	added := 0
+	for element := range ch {
+		c.mu.Lock()
+		if !c.set.Exists(element) {
+			c.set.Insert(element)
+			added++
+		}
+		c.mu.Unlock()
+	}
+	return added
+}
+
+// LastInserted returns the most recently inserted element under the read lock.
+func (c *ConcurrentSet[T]) LastInserted() (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.LastInserted()
+}
+
+// InsertTracked adds the specified element under the write lock
+// and reports whether the backing slice was reallocated.
+func (c *ConcurrentSet[T]) InsertTracked(data T) (idx int, grew bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.InsertTracked(data)
+}
+
+// DeleteTracked removes the specified element under the write lock
+// and reports which element, if any, was moved into its slot.
+func (c *ConcurrentSet[T]) DeleteTracked(element T) (deletedIdx int, movedElem T, moved bool, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.DeleteTracked(element)
+}
+
+// DeleteMany removes all given elements from the set under a single write lock.
+func (c *ConcurrentSet[T]) DeleteMany(data ...T) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.DeleteMany(data...)
+}
+
+// ForEach calls fn for each element of the set while holding the read lock.
+// fn must not call methods that modify the set, or it will deadlock.
+func (c *ConcurrentSet[T]) ForEach(fn func(T) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.set.ForEach(fn)
+}
+
+// All returns an iterator over the elements of the set.
+// The read lock is held for the duration of the loop, so the loop body must not modify the set.
+func (c *ConcurrentSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		c.set.All()(yield)
+	}
+}
+
+// DrainRandom returns an iterator that removes and yields random elements until the set is empty.
+// The write lock is held only while each element is removed, so the loop body may use the set
+// and other goroutines may keep adding work while it is drained.
+func (c *ConcurrentSet[T]) DrainRandom() iter.Seq[T] {
+	return drainRandom(c.Pop)
+}
+
+// String formats the set under the read lock, in the same way as Set.String.
+func (c *ConcurrentSet[T]) String() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.String()
+}
+
+// Snapshot captures the current contents of the set under the read lock.
+func (c *ConcurrentSet[T]) Snapshot() Snapshot[T] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Snapshot()
+}
+
+// Restore resets the set to the contents captured by snap under the write lock.
+func (c *ConcurrentSet[T]) Restore(snap Snapshot[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.Restore(snap)
+}
+
+// Checkpoint saves the current contents of the set under name while holding the write lock.
+func (c *ConcurrentSet[T]) Checkpoint(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.Checkpoint(name)
+}
+
+// Rollback restores the set to the checkpoint saved under name while holding the write lock.
+func (c *ConcurrentSet[T]) Rollback(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.Rollback(name)
+}
+
+// GetRandomN returns up to n distinct random elements from the set under the read lock.
+func (c *ConcurrentSet[T]) GetRandomN(n int) []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.randMu.Lock()
+	defer c.randMu.Unlock()
+	return c.set.GetRandomN(n)
+}
+
+// Cap returns the capacity of the backing slice under the read lock.
+func (c *ConcurrentSet[T]) Cap() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Cap()
+}
+
+// Compact shrinks the backing storage under the write lock.
+func (c *ConcurrentSet[T]) Compact() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.Compact()
+}
+
+// Grow reserves room for n more elements under the write lock.
+func (c *ConcurrentSet[T]) Grow(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.Grow(n)
+}
+
+// Filter returns a new concurrency-safe set of the elements for which pred returns true.
+// The read lock is held while pred runs, so pred must not modify the set.
+func (c *ConcurrentSet[T]) Filter(pred func(T) bool) SnapSet[T] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return &ConcurrentSet[T]{set: c.set.Filter(pred).(*Set[T])}
+}
+
+// Partition splits the set by pred into two new concurrency-safe sets under the read lock.
+// pred must not modify the set.
+func (c *ConcurrentSet[T]) Partition(pred func(T) bool) (matched, unmatched SnapSet[T]) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	in, out := c.set.Partition(pred)
+	return &ConcurrentSet[T]{set: in.(*Set[T])}, &ConcurrentSet[T]{set: out.(*Set[T])}
+}
+
+// RemoveIf deletes every element for which pred returns true under the write lock.
+// pred must not call methods of the set, or it will deadlock.
+func (c *ConcurrentSet[T]) RemoveIf(pred func(T) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.RemoveIf(pred)
+}
+
+// IndexOf returns the current index of the specified element under the read lock.
+func (c *ConcurrentSet[T]) IndexOf(element T) (int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.IndexOf(element)
+}
+
+// At returns the element stored at the specified index under the read lock.
+func (c *ConcurrentSet[T]) At(idx int) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.At(idx)
+}
+
+// ContainsAll reports whether every given element exists in the set under the read lock.
+func (c *ConcurrentSet[T]) ContainsAll(elems ...T) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.ContainsAll(elems...)
+}
+
+// ContainsAny reports whether at least one given element exists in the set under the read lock.
+func (c *ConcurrentSet[T]) ContainsAny(elems ...T) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.ContainsAny(elems...)
+}
+
+// ExistsAll reports the membership of each given element under a single read lock.
+func (c *ConcurrentSet[T]) ExistsAll(elems []T) []bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.ExistsAll(elems)
+}
+
+// Missing returns the elements among elems that do not exist in the set under the read lock.
+func (c *ConcurrentSet[T]) Missing(elems ...T) []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Missing(elems...)
+}
+
+// IterateSafe calls fn for a snapshot of the elements, taken under the read lock,
+// and removes each element for which fn returns false under the write lock.
+// fn runs without any lock held, so it may use the set.
+func (c *ConcurrentSet[T]) IterateSafe(fn func(T) (keep bool)) {
+	for _, element := range c.ToSlice() {
+		if fn(element) {
+			continue
+		}
+		c.mu.Lock()
+		if idx, ok := c.set.bucket[element]; ok {
+			c.set.removeAt(idx)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// Merge inserts every element of other into the set under the write lock.
+// The elements of other are copied before the lock is acquired, so that other
+// is never locked while this set's lock is held.
+func (c *ConcurrentSet[T]) Merge(other SnapSet[T]) int {
+	elements := other.ToSlice()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.InsertMany(elements...)
+}
+
+// RetainAll removes every element not present in other under the write lock.
+// If other is itself a ConcurrentSet, it is copied before the lock is acquired.
+func (c *ConcurrentSet[T]) RetainAll(other SnapSet[T]) int {
+	keep := detach(other)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.RetainAll(keep)
+}
+
+// RemoveAll removes every element of other from the set under the write lock.
+// The elements of other are copied before the lock is acquired, so that other
+// is never locked while this set's lock is held.
+func (c *ConcurrentSet[T]) RemoveAll(other SnapSet[T]) int {
+	elements := other.ToSlice()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.DeleteMany(elements...)
+}
+
+// Shuffle randomly permutes the internal order of the elements under the write lock.
+func (c *ConcurrentSet[T]) Shuffle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.Shuffle()
+}
+
+// AllIndexed returns an iterator over the index and element pairs of the set.
+// The read lock is held for the duration of the loop, so the loop body must not modify the set.
+func (c *ConcurrentSet[T]) AllIndexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		c.set.AllIndexed()(yield)
+	}
+}
+
+// PopN removes and returns up to n random elements from the set under the write lock.
+func (c *ConcurrentSet[T]) PopN(n int) []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.PopN(n)
+}
+
+// Freeze returns a read-only view of the set whose methods still take the set's locks.
+func (c *ConcurrentSet[T]) Freeze() ReadOnlySet[T] {
+	return frozenSet[T]{set: c}
+}
+
+// CopyInto copies as many elements as fit into dst under the read lock.
+func (c *ConcurrentSet[T]) CopyInto(dst []T) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.CopyInto(dst)
+}
+
+// IsDisjoint reports whether the set and other have no elements in common, under the read lock.
+// If other is itself a ConcurrentSet, the smaller of the two sets is copied first.
+func (c *ConcurrentSet[T]) IsDisjoint(other SnapSet[T]) bool {
+	if o, ok := c.larger(other); ok {
+		return o.IsDisjoint(c.plain())
+	}
+	other = detach(other)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.IsDisjoint(other)
+}
+
+// IntersectionCount returns the number of elements the set shares with other, under the read lock.
+// If other is itself a ConcurrentSet, the smaller of the two sets is copied first.
+func (c *ConcurrentSet[T]) IntersectionCount(other SnapSet[T]) int {
+	if o, ok := c.larger(other); ok {
+		return o.IntersectionCount(c.plain())
+	}
+	other = detach(other)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.IntersectionCount(other)
+}
+
+// GetRandomExcept returns a random element that is not in exclude under the read lock.
+// If exclude is itself a ConcurrentSet, the smaller of the two sets is copied first:
+// either exclude, before this set's lock is acquired, or this set, whose remaining
+// elements are then picked from under exclude's read lock.
+func (c *ConcurrentSet[T]) GetRandomExcept(exclude SnapSet[T]) (T, bool) {
+	if o, ok := c.larger(exclude); ok {
+		eligible := c.ToSlice()
+		o.mu.RLock()
+		eligible = slices.DeleteFunc(eligible, o.set.Exists)
+		o.mu.RUnlock()
+		if len(eligible) == 0 {
+			var zero T
+			return zero, false
+		}
+
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		c.randMu.Lock()
+		defer c.randMu.Unlock()
+		return eligible[c.set.rand.Intn(len(eligible))], true
+	}
+	exclude = detach(exclude)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.randMu.Lock()
+	defer c.randMu.Unlock()
+	return c.set.GetRandomExcept(exclude)
+}
+
+// larger returns other as a ConcurrentSet if it is one holding more elements than c.
+// Copying c and consulting other under its own lock is then cheaper than detaching other.
+// The lengths may change before the copy is taken, so this only picks the cheaper side.
+func (c *ConcurrentSet[T]) larger(other SnapSet[T]) (*ConcurrentSet[T], bool) {
+	o, ok := other.(*ConcurrentSet[T])
+	if !ok || c.Len() >= o.Len() {
+		return nil, false
+	}
+	return o, true
+}
+
+// plain returns a plain copy of the set taken under the read lock.
+func (c *ConcurrentSet[T]) plain() SnapSet[T] {
+	return NewFromSlice(c.ToSlice())
+}
+
+// detach returns a plain copy of other if it is a ConcurrentSet, and other itself otherwise.
+// It lets a ConcurrentSet consult another set while holding its own lock without
+// risking a deadlock on the other set's lock.
+func detach[T comparable](other SnapSet[T]) SnapSet[T] {
+	if _, ok := other.(*ConcurrentSet[T]); ok {
+		return NewFromSlice(other.ToSlice())
+	}
+	return other
+}
+
+// GetRandomIndexed returns a random element and its index under the read lock.
+// Another goroutine may delete elements as soon as the lock is released,
+// so callers that act on the index should hold their own coordination.
+func (c *ConcurrentSet[T]) GetRandomIndexed() (T, int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.randMu.Lock()
+	defer c.randMu.Unlock()
+	return c.set.GetRandomIndexed()
+}
+
+// GetRandomFrom returns a random element chosen with r under the read lock.
+// It does not contend on the set's own generator.
+func (c *ConcurrentSet[T]) GetRandomFrom(r *rand.Rand) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.GetRandomFrom(r)
+}
+
+// GetRandomWithReplacement returns n random elements, allowing repeats, under the read lock.
+func (c *ConcurrentSet[T]) GetRandomWithReplacement(n int) []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.randMu.Lock()
+	defer c.randMu.Unlock()
+	return c.set.GetRandomWithReplacement(n)
+}
+
+// InsertIfAbsent adds the specified element if it is absent, atomically under the write lock.
+func (c *ConcurrentSet[T]) InsertIfAbsent(data T) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.InsertIfAbsent(data)
+}
+
+// Replace swaps oldElement for newElement at the same index under the write lock.
+func (c *ConcurrentSet[T]) Replace(oldElement, newElement T) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.Replace(oldElement, newElement)
+}
+
+// Count returns how many elements satisfy pred under the read lock.
+// pred must not modify the set.
+func (c *ConcurrentSet[T]) Count(pred func(T) bool) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Count(pred)
+}
+
+// Any reports whether at least one element satisfies pred under the read lock.
+func (c *ConcurrentSet[T]) Any(pred func(T) bool) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Any(pred)
+}
+
+// Every reports whether every element satisfies pred under the read lock.
+func (c *ConcurrentSet[T]) Every(pred func(T) bool) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Every(pred)
+}
+
+// SetRand replaces the set's generator under the write lock,
+// so no reader can be drawing from the old generator at the same time.
+func (c *ConcurrentSet[T]) SetRand(r *rand.Rand) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.SetRand(r)
+}
+
+// Stream sends a snapshot of the elements, taken under the read lock, over the returned channel.
+// The lock is released before Stream returns, so slow consumers do not block writers.
+func (c *ConcurrentSet[T]) Stream(ctx context.Context) <-chan T {
+	return stream(ctx, c.ToSlice())
+}
+
+// Zero returns the zero value of T. It reads no state, so it takes no lock.
+func (c *ConcurrentSet[T]) Zero() T {
+	var zero T
+	return zero
+}
+
+// ContentHash returns an order-independent hash of the set's contents under the read lock.
+func (c *ConcurrentSet[T]) ContentHash() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.ContentHash()
+}
@@ -0,0 +1,159 @@
+package snapset_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestConcurrentInsertDelete runs concurrent inserts, deletes and reads.
+// Run with -race to detect unsynchronized access.
+func TestConcurrentInsertDelete(t *testing.T) {
+	s := snapset.NewConcurrent[int](snapset.DefaultBucketSize)
+
+	const workers = 8
+	const perWorker = 500
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(base int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				val := base*perWorker + i
+				s.Insert(val)
+				s.Exists(val)
+				s.GetRandomOK()
+				if i%2 == 0 {
+					s.Delete(val)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// Every worker deleted the even half of its elements
+	expected := workers * perWorker / 2
+	if s.Len() != expected {
+		t.Errorf("Expected length %d, got %d", expected, s.Len())
+	}
+
+	for _, val := range s.ToSlice() {
+		if val%2 == 0 {
+			t.Errorf("Element %d should have been deleted", val)
+		}
+	}
+}
+
+// TestConcurrentSelfComparison checks that comparing a concurrent set with itself does not deadlock.
+func TestConcurrentSelfComparison(t *testing.T) {
+	s := snapset.NewConcurrent[int](snapset.DefaultBucketSize)
+	s.Insert(1)
+	s.Insert(2)
+
+	if !s.Equal(s) || !s.IsSubset(s) || !s.IsSuperset(s) {
+		t.Errorf("A set should be equal to, a subset of and a superset of itself")
+	}
+
+	c := s.Clone()
+	c.Insert(3)
+	if s.Exists(3) {
+		t.Errorf("Original set should not observe mutations of the clone")
+	}
+}
+
+// TestConcurrentCompareSizes checks the comparisons between concurrent sets in both size orders,
+// since only the smaller of the two sets is copied.
+func TestConcurrentCompareSizes(t *testing.T) {
+	small := snapset.NewConcurrent[int](snapset.DefaultBucketSize)
+	small.InsertMany(1, 2)
+	large := snapset.NewConcurrent[int](snapset.DefaultBucketSize)
+	large.InsertMany(1, 2, 3, 4)
+
+	if !small.IsSubset(large) || large.IsSubset(small) {
+		t.Errorf("Expected only the smaller set to be a subset of the larger one")
+	}
+	if small.Equal(large) || large.Equal(small) {
+		t.Errorf("Expected sets of different sizes to be unequal")
+	}
+	if small.IsDisjoint(large) || large.IsDisjoint(small) {
+		t.Errorf("Expected the sets to overlap")
+	}
+	if n := small.IntersectionCount(large); n != 2 {
+		t.Errorf("Expected an intersection count of 2, got %d", n)
+	}
+	if n := large.IntersectionCount(small); n != 2 {
+		t.Errorf("Expected an intersection count of 2, got %d", n)
+	}
+	if val, ok := small.GetRandomExcept(large); ok {
+		t.Errorf("Expected no element outside the larger set, got %d", val)
+	}
+	if val, ok := large.GetRandomExcept(small); !ok || val < 3 {
+		t.Errorf("Expected 3 or 4, got %d, %v", val, ok)
+	}
+	small.Insert(5)
+	if val, ok := small.GetRandomExcept(large); !ok || val != 5 {
+		t.Errorf("Expected 5, got %d, %v", val, ok)
+	}
+}
+
+// TestConcurrentPop checks that concurrent Pop, PopN and Insert never hand out an element twice.
+// Run with -race to detect unsynchronized access.
+func TestConcurrentPop(t *testing.T) {
+	s := snapset.NewConcurrent[int](snapset.DefaultBucketSize)
+
+	const workers = 8
+	const perWorker = 500
+
+	var mu sync.Mutex
+	popped := make(map[int]int)
+	record := func(vals ...int) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, val := range vals {
+			popped[val]++
+		}
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+
+		// Producer
+		go func(base int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				s.Insert(base*perWorker + i)
+			}
+		}(w)
+
+		// Consumer mixing single and batch pops with reads
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker/4; i++ {
+				if val, ok := s.Pop(); ok {
+					record(val)
+				}
+				record(s.PopN(2)...)
+				s.GetRandomN(3)
+				s.Shuffle()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Drain whatever is left
+	for val := range s.DrainRandom() {
+		record(val)
+	}
+
+	if len(popped) != workers*perWorker {
+		t.Errorf("Expected %d distinct elements, got %d", workers*perWorker, len(popped))
+	}
+	for val, n := range popped {
+		if n != 1 {
+			t.Errorf("Element %d was popped %d times", val, n)
+		}
+	}
+}
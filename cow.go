@@ -0,0 +1,52 @@
+package snapset
+
+import (
+	"maps"
+	"slices"
+)
+
+// cowRefs counts the sets sharing one bucket map and list after CloneCOW.
+type cowRefs struct {
+	n int
+}
+
+// CloneCOW returns a copy-on-write clone of the set.
+// The clone shares the bucket map and list with the receiver until either of them is modified,
+// at which point the modified set copies the storage for itself. Cloning is therefore O(1),
+// which suits workloads that clone often but rarely diverge, such as speculative branches.
+// Like Clone, it keeps the kind of random source but does not carry over hooks,
+// checkpoints or the eviction callback.
+// Sets sharing storage must not be used from different goroutines without common synchronization.
+func (s *Set[T]) CloneCOW() SnapSet[T] {
+	if s.cow == nil {
+		s.cow = &cowRefs{n: 1}
+	}
+	s.cow.n++
+
+	return &Set[T]{
+		bucket:     s.bucket,
+		list:       s.list,
+		currIdx:    s.currIdx,
+		rand:       s.spawnRand(),
+		newRand:    s.newRand,
+		bucketHint: s.bucketHint,
+		maxSize:    s.maxSize,
+		cow:        s.cow,
+		growth:     s.growth,
+	}
+}
+
+// own gives the set private copies of its bucket map and list if it still shares them
+// with a copy-on-write clone. Every method that writes to either must call it first.
+func (s *Set[T]) own() {
+	if s.cow == nil {
+		return
+	}
+
+	if s.cow.n > 1 {
+		s.cow.n--
+		s.bucket = maps.Clone(s.bucket)
+		s.list = slices.Clone(s.list)
+	}
+	s.cow = nil
+}
@@ -0,0 +1,35 @@
+package snapset_test
+
+import (
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestCloneCOW checks that copy-on-write clones diverge independently.
+func TestCloneCOW(t *testing.T) {
+	s := snapset.NewFromSlice([]int{1, 2, 3})
+
+	// Take two clones sharing the same storage
+	a := s.CloneCOW()
+	b := s.CloneCOW()
+	assertElements(t, a, 1, 2, 3)
+
+	// Modifying a clone leaves the others untouched
+	a.Insert(4)
+	a.Delete(1)
+	assertElements(t, a, 2, 3, 4)
+	assertElements(t, s, 1, 2, 3)
+	assertElements(t, b, 1, 2, 3)
+
+	// Modifying the original leaves the remaining clone untouched
+	s.Insert(5)
+	s.Pop()
+	assertElements(t, b, 1, 2, 3)
+
+	// The last holder of the storage modifies it in place
+	b.Clear()
+	b.Insert(9)
+	assertElements(t, b, 9)
+	assertElements(t, a, 2, 3, 4)
+}
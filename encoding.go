@@ -0,0 +1,226 @@
+package snapset
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// MarshalJSON implements json.Marshaler, encoding the set as a JSON array of its elements.
+// The order of the elements in the array is unspecified.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding a JSON array into the set.
+// Any existing contents are replaced, and the bucket map and list are rebuilt from the array.
+// Duplicate values in the array are collapsed: the first occurrence is kept and later ones are ignored.
+// The set gets a freshly seeded random number generator.
+// On error the set is left unchanged.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	s.reset(len(elements))
+	s.InsertMany(elements...)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder, encoding only the elements of the set.
+func (s *Set[T]) GobEncode() ([]byte, error) {
+	return gobEncode(s.ToSlice())
+}
+
+// gobEncode encodes elements as a gob-encoded slice for GobEncode.
+func gobEncode[T any](elements []T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(elements); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder, replacing the contents of the set with the decoded elements.
+// Like UnmarshalJSON, it rebuilds the bucket map and list and reseeds the random number generator.
+// On error the set is left unchanged.
+func (s *Set[T]) GobDecode(data []byte) error {
+	var elements []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&elements); err != nil {
+		return err
+	}
+
+	s.reset(len(elements))
+	s.InsertMany(elements...)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the set as a comma-separated list,
+// e.g. for a config field or a log line. The order of the elements is unspecified.
+// String-kinded elements are written as is, elements implementing encoding.TextMarshaler
+// use their text form, and all other elements are written as JSON.
+// Commas and backslashes inside an element are escaped with a backslash.
+// Both an empty set and a set holding only the empty string encode as empty text,
+// which decodes to an empty set.
+func (s *Set[T]) MarshalText() ([]byte, error) {
+	return marshalText(s.list)
+}
+
+// marshalText writes elements as an escaped, comma-separated list for MarshalText.
+func marshalText[T any](elements []T) ([]byte, error) {
+	var buf bytes.Buffer
+	for i, element := range elements {
+		text, err := marshalElement(element)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		for _, b := range text {
+			if b == ',' || b == '\\' {
+				buf.WriteByte('\\')
+			}
+			buf.WriteByte(b)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding a comma-separated list
+// produced by MarshalText into the set. Like UnmarshalJSON, it replaces any existing contents,
+// collapses duplicates and reseeds the random number generator. On error the set is left unchanged.
+func (s *Set[T]) UnmarshalText(text []byte) error {
+	fields, err := splitText(text)
+	if err != nil {
+		return err
+	}
+
+	elements := make([]T, len(fields))
+	for i, field := range fields {
+		if err := unmarshalElement(field, &elements[i]); err != nil {
+			return err
+		}
+	}
+
+	s.reset(len(elements))
+	s.InsertMany(elements...)
+	return nil
+}
+
+// marshalElement returns the text form of a single element for MarshalText.
+func marshalElement[T any](element T) ([]byte, error) {
+	if m, ok := any(element).(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+	if v := reflect.ValueOf(element); v.Kind() == reflect.String {
+		return []byte(v.String()), nil
+	}
+	return json.Marshal(element)
+}
+
+// unmarshalElement decodes the text form of a single element, the inverse of marshalElement.
+func unmarshalElement[T any](text []byte, element *T) error {
+	if u, ok := any(element).(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText(text)
+	}
+	if v := reflect.ValueOf(element).Elem(); v.Kind() == reflect.String {
+		v.SetString(string(text))
+		return nil
+	}
+	return json.Unmarshal(text, element)
+}
+
+// splitText splits text at unescaped commas and removes the escaping backslashes.
+// Empty text yields no fields.
+func splitText(text []byte) ([][]byte, error) {
+	if len(text) == 0 {
+		return nil, nil
+	}
+
+	var fields [][]byte
+	field := []byte{}
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+			if i == len(text) {
+				return nil, errors.New("snapset: unterminated escape in text")
+			}
+			field = append(field, text[i])
+		case ',':
+			fields = append(fields, field)
+			field = []byte{}
+		default:
+			field = append(field, text[i])
+		}
+	}
+	return append(fields, field), nil
+}
+
+// reset empties the set in preparation for decoding size elements into it
+// and reseeds its random number generator. A zero Set is initialized first.
+func (s *Set[T]) reset(size int) {
+	if s.bucket == nil {
+		*s = *newSet[T](size, newSource())
+		return
+	}
+
+	s.Clear()
+	s.rand = s.spawnRand()
+}
+
+// MarshalJSON encodes the set as a JSON array under the read lock.
+func (c *ConcurrentSet[T]) MarshalJSON() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.MarshalJSON()
+}
+
+// UnmarshalJSON decodes a JSON array into the set under the write lock.
+func (c *ConcurrentSet[T]) UnmarshalJSON(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set == nil {
+		c.set = &Set[T]{}
+	}
+	return c.set.UnmarshalJSON(data)
+}
+
+// MarshalText encodes the set as a comma-separated list under the read lock.
+func (c *ConcurrentSet[T]) MarshalText() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.MarshalText()
+}
+
+// UnmarshalText decodes a comma-separated list into the set under the write lock.
+func (c *ConcurrentSet[T]) UnmarshalText(text []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set == nil {
+		c.set = &Set[T]{}
+	}
+	return c.set.UnmarshalText(text)
+}
+
+// GobEncode encodes the elements of the set under the read lock.
+func (c *ConcurrentSet[T]) GobEncode() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.GobEncode()
+}
+
+// GobDecode decodes elements into the set under the write lock.
+func (c *ConcurrentSet[T]) GobDecode(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set == nil {
+		c.set = &Set[T]{}
+	}
+	return c.set.GobDecode(data)
+}
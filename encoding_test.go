@@ -0,0 +1,139 @@
+package snapset_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestJSON checks JSON marshaling and unmarshaling.
+func TestJSON(t *testing.T) {
+	s := snapset.NewFromSlice([]string{"a", "b", "c"})
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Failed to marshal set: %v", err)
+	}
+
+	// Decode into a zero value set
+	var decoded snapset.Set[string]
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal set: %v", err)
+	}
+	assertElements[string](t, &decoded, "a", "b", "c")
+
+	// An empty set encodes as an empty array
+	data, _ = json.Marshal(snapset.New[int](0))
+	if string(data) != "[]" {
+		t.Errorf("Expected [], got %s", data)
+	}
+}
+
+// TestUnmarshalJSONDuplicates checks that duplicates collapse and existing contents are replaced.
+func TestUnmarshalJSONDuplicates(t *testing.T) {
+	s := snapset.NewFromSlice([]int{7, 8})
+
+	if err := json.Unmarshal([]byte("[1, 2, 1, 3, 2]"), s); err != nil {
+		t.Fatalf("Failed to unmarshal set: %v", err)
+	}
+	assertElements(t, s, 1, 2, 3)
+
+	// The rebuilt set must stay consistent
+	if _, ok := s.Delete(1); !ok || s.Len() != 2 {
+		t.Errorf("Set should remain usable after unmarshaling")
+	}
+
+	// Invalid input leaves the set untouched
+	if err := json.Unmarshal([]byte(`{"not": "an array"}`), s); err == nil {
+		t.Errorf("Expected an error for a JSON object")
+	}
+	assertElements(t, s, 2, 3)
+}
+
+// TestConcurrentJSON checks that a concurrent set round-trips through JSON.
+func TestConcurrentJSON(t *testing.T) {
+	s := snapset.NewConcurrent[int](snapset.DefaultBucketSize)
+	s.InsertMany(1, 2)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Failed to marshal set: %v", err)
+	}
+
+	var decoded snapset.ConcurrentSet[int]
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal set: %v", err)
+	}
+	assertElements[int](t, &decoded, 1, 2)
+}
+
+// TestGob checks gob encoding and decoding.
+func TestGob(t *testing.T) {
+	s := snapset.NewFromSlice([]int{1, 2, 3})
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		t.Fatalf("Failed to gob-encode set: %v", err)
+	}
+
+	decoded := snapset.New[int](0)
+	if err := gob.NewDecoder(&buf).Decode(decoded); err != nil {
+		t.Fatalf("Failed to gob-decode set: %v", err)
+	}
+	assertElements(t, decoded, 1, 2, 3)
+
+	// The random generator works after decoding
+	if _, ok := decoded.GetRandomOK(); !ok {
+		t.Errorf("Expected a random element from the decoded set")
+	}
+
+	// Corrupt input is rejected
+	var set snapset.Set[int]
+	if err := set.GobDecode([]byte("garbage")); err == nil {
+		t.Errorf("Expected an error for corrupt gob data")
+	}
+}
+
+// TestText checks comma-separated text encoding, including escaping.
+func TestText(t *testing.T) {
+	s := snapset.NewFromSlice([]string{"beta", "a,b", `back\slash`})
+
+	data, err := s.(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		t.Fatalf("Failed to marshal set: %v", err)
+	}
+
+	var decoded snapset.Set[string]
+	if err = decoded.UnmarshalText(data); err != nil {
+		t.Fatalf("Failed to unmarshal set: %v", err)
+	}
+	assertElements[string](t, &decoded, "beta", "a,b", `back\slash`)
+
+	// Non-string elements use their JSON form
+	ints := snapset.NewFromSlice([]int{3})
+	if data, _ = ints.(encoding.TextMarshaler).MarshalText(); string(data) != "3" {
+		t.Errorf("Expected 3, got %s", data)
+	}
+	if err = ints.(encoding.TextUnmarshaler).UnmarshalText([]byte("1,2,1")); err != nil {
+		t.Fatalf("Failed to unmarshal set: %v", err)
+	}
+	assertElements(t, ints, 1, 2)
+
+	// Invalid input leaves the set untouched
+	if err = ints.(encoding.TextUnmarshaler).UnmarshalText([]byte("1,x")); err == nil {
+		t.Errorf("Expected an error for a non-numeric element")
+	}
+	if err = decoded.UnmarshalText([]byte(`trailing\`)); err == nil {
+		t.Errorf("Expected an error for an unterminated escape")
+	}
+	assertElements(t, ints, 1, 2)
+
+	// Empty text decodes to an empty set
+	if err = decoded.UnmarshalText(nil); err != nil || decoded.Len() != 0 {
+		t.Errorf("Expected an empty set, got %v and %v", &decoded, err)
+	}
+}
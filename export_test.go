@@ -0,0 +1,7 @@
+package snapset
+
+// SeedRand reseeds the generator of a plain Set, for tests that need to tell
+// a seedable math/rand source apart from crypto/rand, which ignores seeds.
+func SeedRand[T comparable](s SnapSet[T], seed int64) {
+	s.(*Set[T]).rand.Seed(seed)
+}
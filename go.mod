@@ -1,3 +1,3 @@
 module github.com/snapset
 
-go 1.22.0
+go 1.24.0
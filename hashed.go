@@ -0,0 +1,225 @@
+package snapset
+
+import (
+	"iter"
+	"math/rand"
+)
+
+// Hasher defines how a HashSet hashes and compares its elements.
+// Equal values must produce equal hashes. Hash should be cheap relative to comparing
+// whole values, for example by hashing only an identifying field of a large struct.
+type Hasher[T any] interface {
+	// Hash returns the hash of the value.
+	Hash(T) uint64
+
+	// Equal reports whether two values are the same element.
+	Equal(a, b T) bool
+}
+
+// HashSet is a set whose membership is decided by a user-supplied Hasher rather than
+// Go's built-in map hashing, which hashes and compares whole values.
+// Elements are stored in a slice, like Set, and located through an open-addressing table
+// with linear probing that caches each element's hash, so Equal is only called on hash matches.
+// Note: HashSet is not safe for concurrent use.
+type HashSet[T any] struct {
+	hasher Hasher[T]
+	slots  []hashSlot // open-addressing table; its length is always a power of two
+	list   []T        // stores the elements
+	hashes []uint64   // hash of each element, aligned with list
+	rand   *rand.Rand // random number generator for GetRandom
+}
+
+// hashSlot is an entry of the open-addressing table of a HashSet.
+type hashSlot struct {
+	hash uint64 // cached hash of the element
+	ref  int    // index of the element in the list plus one; zero marks an empty slot
+}
+
+// minHashSlots is the smallest table size of a HashSet.
+const minHashSlots = 8
+
+// NewHashed creates and returns a new HashSet with room for size elements
+// before its table has to grow, using hasher to hash and compare elements.
+func NewHashed[T any](size int, hasher Hasher[T]) *HashSet[T] {
+	h := &HashSet[T]{
+		hasher: hasher,
+		rand:   rand.New(newSource()),
+	}
+	h.rehash(max(size, 0))
+	return h
+}
+
+// Insert adds the specified element to the set.
+// If an equal element already exists, the set is left unchanged and its existing index is returned.
+// It returns the index of the inserted element.
+func (h *HashSet[T]) Insert(data T) int {
+	hash := h.hasher.Hash(data)
+	if pos := h.find(data, hash); pos >= 0 {
+		return h.slots[pos].ref - 1 // Element already exists
+	}
+
+	// Keep the load factor at or below 3/4
+	if (len(h.list)+1)*4 > len(h.slots)*3 {
+		h.rehash(len(h.list) + 1)
+	}
+
+	h.list = append(h.list, data)
+	h.hashes = append(h.hashes, hash)
+	h.place(hash, len(h.list))
+	return len(h.list) - 1
+}
+
+// Delete removes the specified element from the set, using the same swap-delete strategy as Set.
+// It returns the index of the deleted element and true, or 0 and false if it does not exist.
+func (h *HashSet[T]) Delete(element T) (int, bool) {
+	pos := h.find(element, h.hasher.Hash(element))
+	if pos < 0 {
+		return 0, false // Element does not exist
+	}
+
+	idx := h.slots[pos].ref - 1
+	lastIdx := len(h.list) - 1
+
+	// Move the last element into the freed slot of the list and repoint its table entry
+	if idx != lastIdx {
+		h.list[idx] = h.list[lastIdx]
+		h.hashes[idx] = h.hashes[lastIdx]
+		h.slots[h.slotOf(lastIdx)].ref = idx + 1
+	}
+
+	var zero T
+	h.list[lastIdx] = zero
+	h.list = h.list[:lastIdx]
+	h.hashes = h.hashes[:lastIdx]
+
+	h.unplace(pos)
+	return idx, true
+}
+
+// Exists checks whether an element equal to the specified one exists in the set.
+func (h *HashSet[T]) Exists(element T) bool {
+	return h.find(element, h.hasher.Hash(element)) >= 0
+}
+
+// GetRandom returns a random element from the set. It panics if the set is empty.
+func (h *HashSet[T]) GetRandom() T {
+	return h.list[h.rand.Intn(len(h.list))]
+}
+
+// GetRandomOK returns a random element from the set and true,
+// or the zero value of T and false if the set is empty.
+func (h *HashSet[T]) GetRandomOK() (T, bool) {
+	if len(h.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.GetRandom(), true
+}
+
+// Len returns the number of elements in the set.
+func (h *HashSet[T]) Len() int {
+	return len(h.list)
+}
+
+// ContentHash returns a hash of the set's contents that does not depend on the order of the elements,
+// for element types that maphash cannot hash. It mixes the Hasher's hash of every element
+// and sums the results, so it is as stable as the Hasher: with a deterministic Hasher,
+// equal sets hash equally even across processes.
+func (h *HashSet[T]) ContentHash() uint64 {
+	var sum uint64
+	for _, element := range h.list {
+		sum += mix64(h.hasher.Hash(element))
+	}
+	return sum
+}
+
+// mix64 scrambles the bits of x with the SplitMix64 finalizer, so that summing
+// the hashes of a weak Hasher, such as the identity on integers, still spreads well.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// ToSlice returns a copy of all elements in the set, in no particular order.
+func (h *HashSet[T]) ToSlice() []T {
+	out := make([]T, len(h.list))
+	copy(out, h.list)
+	return out
+}
+
+// All returns an iterator over the elements of the set, in no particular order.
+// Modifying the set during iteration is unsupported.
+func (h *HashSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range h.list {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// find returns the table position of the element with the given hash, or -1 if it is absent.
+func (h *HashSet[T]) find(element T, hash uint64) int {
+	mask := len(h.slots) - 1
+	for pos := int(hash) & mask; h.slots[pos].ref != 0; pos = (pos + 1) & mask {
+		slot := h.slots[pos]
+		if slot.hash == hash && h.hasher.Equal(h.list[slot.ref-1], element) {
+			return pos
+		}
+	}
+	return -1
+}
+
+// slotOf returns the table position referring to the element at idx in the list.
+func (h *HashSet[T]) slotOf(idx int) int {
+	mask := len(h.slots) - 1
+	pos := int(h.hashes[idx]) & mask
+	for h.slots[pos].ref != idx+1 {
+		pos = (pos + 1) & mask
+	}
+	return pos
+}
+
+// place stores a reference to the list entry ref-1 in the first free slot of its probe sequence.
+func (h *HashSet[T]) place(hash uint64, ref int) {
+	mask := len(h.slots) - 1
+	pos := int(hash) & mask
+	for h.slots[pos].ref != 0 {
+		pos = (pos + 1) & mask
+	}
+	h.slots[pos] = hashSlot{hash: hash, ref: ref}
+}
+
+// unplace empties the slot at pos and shifts later entries of the probe run backwards,
+// so lookups never stop early at the hole and no tombstones are needed.
+func (h *HashSet[T]) unplace(pos int) {
+	mask := len(h.slots) - 1
+	for next := (pos + 1) & mask; h.slots[next].ref != 0; next = (next + 1) & mask {
+		home := int(h.slots[next].hash) & mask
+
+		// The entry may move into the hole only if its home is not cyclically within (pos, next]
+		if (next-home)&mask >= (next-pos)&mask {
+			h.slots[pos] = h.slots[next]
+			pos = next
+		}
+	}
+	h.slots[pos] = hashSlot{}
+}
+
+// rehash resizes the table to fit at least size elements and re-inserts every element.
+func (h *HashSet[T]) rehash(size int) {
+	n := minHashSlots
+	for n*3 < size*4 {
+		n <<= 1
+	}
+
+	h.slots = make([]hashSlot, n)
+	for idx, hash := range h.hashes {
+		h.place(hash, idx+1)
+	}
+}
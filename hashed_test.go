@@ -0,0 +1,108 @@
+package snapset_test
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// document is a large value identified by its id.
+type document struct {
+	id   string
+	body [256]byte
+}
+
+// documentHasher hashes and compares documents by id only.
+type documentHasher struct{}
+
+func (documentHasher) Hash(d document) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(d.id))
+	return h.Sum64()
+}
+
+func (documentHasher) Equal(a, b document) bool {
+	return a.id == b.id
+}
+
+// collidingHasher maps every int to one of a few hashes to exercise probing.
+type collidingHasher struct{}
+
+func (collidingHasher) Hash(v int) uint64   { return uint64(v % 3) }
+func (collidingHasher) Equal(a, b int) bool { return a == b }
+
+// TestHashSet checks membership through a custom Hasher.
+func TestHashSet(t *testing.T) {
+	s := snapset.NewHashed[document](0, documentHasher{})
+
+	s.Insert(document{id: "a"})
+	s.Insert(document{id: "b"})
+	if idx := s.Insert(document{id: "a", body: [256]byte{1}}); idx != 0 {
+		t.Errorf("Expected existing index 0, got %d", idx)
+	}
+
+	if s.Len() != 2 || !s.Exists(document{id: "b"}) {
+		t.Errorf("Expected documents a and b to exist")
+	}
+
+	if idx, ok := s.Delete(document{id: "a"}); !ok || idx != 0 {
+		t.Errorf("Expected (0, true), got (%d, %t)", idx, ok)
+	}
+
+	if got, ok := s.GetRandomOK(); !ok || got.id != "b" {
+		t.Errorf("Expected document b to remain, got %q", got.id)
+	}
+}
+
+// TestHashSetAgainstMap compares a HashSet with colliding hashes to a map under random operations.
+func TestHashSetAgainstMap(t *testing.T) {
+	s := snapset.NewHashed[int](snapset.DefaultBucketSize, collidingHasher{})
+	oracle := make(map[int]bool)
+
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 5000; i++ {
+		val := r.Intn(200)
+		if r.Intn(3) == 0 {
+			_, ok := s.Delete(val)
+			if ok != oracle[val] {
+				t.Fatalf("Delete(%d) = %t, expected %t", val, ok, oracle[val])
+			}
+			delete(oracle, val)
+		} else {
+			s.Insert(val)
+			oracle[val] = true
+		}
+	}
+
+	if s.Len() != len(oracle) {
+		t.Errorf("Expected %d elements, got %d", len(oracle), s.Len())
+	}
+
+	for val := 0; val < 200; val++ {
+		if s.Exists(val) != oracle[val] {
+			t.Errorf("Exists(%d) = %t, expected %t", val, s.Exists(val), oracle[val])
+		}
+	}
+}
+
+// TestHashSetContentHash checks the order-independent hash through a custom Hasher.
+func TestHashSetContentHash(t *testing.T) {
+	a := snapset.NewHashed[document](0, documentHasher{})
+	b := snapset.NewHashed[document](0, documentHasher{})
+
+	a.Insert(document{id: "a"})
+	a.Insert(document{id: "b"})
+	b.Insert(document{id: "b"})
+	b.Insert(document{id: "a"})
+
+	if a.ContentHash() != b.ContentHash() {
+		t.Errorf("Expected equal sets to have equal hashes")
+	}
+
+	b.Delete(document{id: "a"})
+	if a.ContentHash() == b.ContentHash() {
+		t.Errorf("Expected different sets to have different hashes")
+	}
+}
@@ -0,0 +1,37 @@
+package snapset
+
+// Hooks holds optional callbacks that a set invokes after its contents change.
+// They let callers maintain derived indexes or emit metrics without wrapping every call site.
+// Hooks only fire for actual changes: re-inserting an existing element or deleting
+// an absent one does not invoke them. A nil callback is skipped.
+type Hooks[T comparable] struct {
+	// OnInsert is called after an element has been added to the set.
+	OnInsert func(T)
+
+	// OnDelete is called after an element has been removed from the set,
+	// whether by Delete, Pop, Clear or any other removing method.
+	OnDelete func(T)
+}
+
+// NewWithHooks creates and returns a new instance of Set with the specified initial size
+// that invokes the given hooks after each successful insertion and removal.
+// Hooks run synchronously on the goroutine performing the mutation and must not modify the set.
+func NewWithHooks[T comparable](size int, hooks Hooks[T]) SnapSet[T] {
+	s := newSet[T](size, newSource())
+	s.hooks = hooks
+	return s
+}
+
+// inserted invokes the OnInsert hook, if any, for a newly added element.
+func (s *Set[T]) inserted(element T) {
+	if s.hooks.OnInsert != nil {
+		s.hooks.OnInsert(element)
+	}
+}
+
+// deleted invokes the OnDelete hook, if any, for a removed element.
+func (s *Set[T]) deleted(element T) {
+	if s.hooks.OnDelete != nil {
+		s.hooks.OnDelete(element)
+	}
+}
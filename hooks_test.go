@@ -0,0 +1,60 @@
+package snapset_test
+
+import (
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestHooks checks that hooks fire exactly once per actual mutation.
+func TestHooks(t *testing.T) {
+	inserted := make(map[string]int)
+	deleted := make(map[string]int)
+
+	s := snapset.NewWithHooks(snapset.DefaultBucketSize, snapset.Hooks[string]{
+		OnInsert: func(val string) { inserted[val]++ },
+		OnDelete: func(val string) { deleted[val]++ },
+	})
+
+	s.Insert("a")
+	s.Insert("a") // Idempotent re-insert
+	s.InsertMany("b", "c")
+	s.Delete("b")
+	s.Delete("zzz") // Absent element
+
+	if inserted["a"] != 1 || inserted["b"] != 1 || inserted["c"] != 1 {
+		t.Errorf("Expected one insert event per element, got %v", inserted)
+	}
+
+	if deleted["b"] != 1 || deleted["zzz"] != 0 || len(deleted) != 1 {
+		t.Errorf("Expected a single delete event for b, got %v", deleted)
+	}
+
+	// Pop and Clear report their removals too
+	popped, _ := s.Pop()
+	s.Clear()
+	if deleted[popped] != 1 || deleted["a"]+deleted["c"] != 2 {
+		t.Errorf("Expected delete events for every removed element, got %v", deleted)
+	}
+}
+
+// TestHooksRestore checks that Restore fires hooks only for the elements that change.
+func TestHooksRestore(t *testing.T) {
+	events := 0
+	s := snapset.NewWithHooks(snapset.DefaultBucketSize, snapset.Hooks[int]{
+		OnInsert: func(int) { events++ },
+		OnDelete: func(int) { events-- },
+	})
+
+	s.InsertMany(1, 2, 3)
+	snap := s.Snapshot()
+	s.Delete(1)
+	s.Insert(4)
+
+	// Restoring adds 1 back and removes 4, so the running count matches the length
+	s.Restore(snap)
+	assertElements(t, s, 1, 2, 3)
+	if events != s.Len() {
+		t.Errorf("Expected hook balance %d, got %d", s.Len(), events)
+	}
+}
@@ -0,0 +1,130 @@
+package snapset
+
+import (
+	"iter"
+	"math/rand"
+	"strings"
+)
+
+// KeyedSet is a set of values of any type whose membership is decided by a key derived
+// from each value, rather than by the value itself. It allows sets of non-comparable types,
+// or of structs identified by a single field, without projecting the data up front.
+// Membership and deduplication use key(element), while GetRandom and ToSlice return full values.
+//
+// When two distinct values share a key, they are considered the same element:
+// the value inserted first is kept, and inserting the second is a no-op.
+// Delete and Exists match any value with the same key.
+// Note: KeyedSet is not safe for concurrent use.
+type KeyedSet[T any, K comparable] struct {
+	bucket map[K]int  // maps keys to the indices of their values in the list
+	list   []T        // stores the values
+	key    func(T) K  // derives the key of a value
+	rand   *rand.Rand // random number generator for GetRandom
+}
+
+// NewKeyed creates and returns a new KeyedSet with the specified initial size,
+// using key to derive the identity of each element.
+func NewKeyed[T any, K comparable](size int, key func(T) K) *KeyedSet[T, K] {
+	return &KeyedSet[T, K]{
+		bucket: make(map[K]int, max(size, 0)),
+		key:    key,
+		rand:   rand.New(newSource()),
+	}
+}
+
+// NewFold creates and returns a new KeyedSet of strings whose membership is case-insensitive.
+// Keys are the lowercased strings, while ToSlice and GetRandom return the first-seen spelling,
+// so Exists("FOO") matches an inserted "foo".
+func NewFold(size int) *KeyedSet[string, string] {
+	return NewKeyed(size, strings.ToLower)
+}
+
+// Insert adds the specified value to the set and returns its index.
+// If a value with the same key already exists, the set is left unchanged
+// and the index of the existing value is returned.
+func (k *KeyedSet[T, K]) Insert(data T) int {
+	key := k.key(data)
+	if idx, ok := k.bucket[key]; ok {
+		return idx // Key already exists
+	}
+
+	k.list = append(k.list, data)
+	k.bucket[key] = len(k.list) - 1
+	return len(k.list) - 1
+}
+
+// Delete removes the value whose key matches that of element, using the same
+// swap-delete strategy as Set.Delete.
+// It returns the index of the deleted value and true, or 0 and false if no value has that key.
+func (k *KeyedSet[T, K]) Delete(element T) (int, bool) {
+	key := k.key(element)
+	idx, ok := k.bucket[key]
+	if !ok {
+		return 0, false // Key does not exist
+	}
+
+	lastIdx := len(k.list) - 1
+
+	// Move the last value into the freed slot and update its index
+	k.list[idx] = k.list[lastIdx]
+	k.bucket[k.key(k.list[idx])] = idx
+
+	var zero T
+	k.list[lastIdx] = zero
+	k.list = k.list[:lastIdx]
+	delete(k.bucket, key)
+
+	return idx, true
+}
+
+// Exists checks whether a value with the same key as element exists in the set.
+func (k *KeyedSet[T, K]) Exists(element T) bool {
+	_, ok := k.bucket[k.key(element)]
+	return ok
+}
+
+// GetRandom returns a random value from the set. It panics if the set is empty.
+func (k *KeyedSet[T, K]) GetRandom() T {
+	return k.list[k.rand.Intn(len(k.list))]
+}
+
+// GetRandomOK returns a random value from the set and true,
+// or the zero value of T and false if the set is empty.
+func (k *KeyedSet[T, K]) GetRandomOK() (T, bool) {
+	if len(k.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return k.GetRandom(), true
+}
+
+// Len returns the number of values in the set.
+func (k *KeyedSet[T, K]) Len() int {
+	return len(k.list)
+}
+
+// Clear removes all values from the set, keeping the allocated storage for reuse.
+func (k *KeyedSet[T, K]) Clear() {
+	clear(k.bucket)
+	clear(k.list)
+	k.list = k.list[:0]
+}
+
+// ToSlice returns a copy of all values in the set, in no particular order.
+func (k *KeyedSet[T, K]) ToSlice() []T {
+	out := make([]T, len(k.list))
+	copy(out, k.list)
+	return out
+}
+
+// All returns an iterator over the values of the set, in no particular order.
+// Modifying the set during iteration is unsupported.
+func (k *KeyedSet[T, K]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range k.list {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
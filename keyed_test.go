@@ -0,0 +1,86 @@
+package snapset_test
+
+import (
+	"testing"
+
+	"github.com/snapset"
+)
+
+// account is a non-comparable type identified by its id.
+type account struct {
+	id    int
+	roles []string
+}
+
+// TestKeyedSet checks membership and deduplication by key.
+func TestKeyedSet(t *testing.T) {
+	s := snapset.NewKeyed(snapset.DefaultBucketSize, func(a account) int { return a.id })
+
+	s.Insert(account{id: 1, roles: []string{"admin"}})
+	s.Insert(account{id: 2})
+
+	// A value with an existing key is ignored and the first value is kept
+	if idx := s.Insert(account{id: 1, roles: []string{"guest"}}); idx != 0 {
+		t.Errorf("Expected existing index 0, got %d", idx)
+	}
+
+	if s.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", s.Len())
+	}
+
+	for _, a := range s.ToSlice() {
+		if a.id == 1 && a.roles[0] != "admin" {
+			t.Errorf("Expected the first inserted value to be kept, got %v", a.roles)
+		}
+	}
+
+	// Membership only looks at the key
+	if !s.Exists(account{id: 2, roles: []string{"other"}}) {
+		t.Errorf("Account 2 should exist regardless of its other fields")
+	}
+
+	// Delete by key and check the moved value stays reachable
+	if idx, ok := s.Delete(account{id: 1}); !ok || idx != 0 {
+		t.Errorf("Expected (0, true), got (%d, %t)", idx, ok)
+	}
+
+	if got, ok := s.GetRandomOK(); !ok || got.id != 2 {
+		t.Errorf("Expected account 2 to remain, got %v", got)
+	}
+
+	if _, ok := s.Delete(account{id: 1}); ok {
+		t.Errorf("Should not be able to delete an absent key")
+	}
+
+	s.Clear()
+	if _, ok := s.GetRandomOK(); ok || s.Len() != 0 {
+		t.Errorf("Expected an empty set after Clear")
+	}
+}
+
+// TestNewFold checks case-insensitive membership with the first spelling preserved.
+func TestNewFold(t *testing.T) {
+	s := snapset.NewFold(snapset.DefaultBucketSize)
+
+	s.Insert("Alice@Example.com")
+	s.Insert("alice@example.com")
+	s.Insert("Content-Type")
+
+	if s.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", s.Len())
+	}
+
+	if !s.Exists("CONTENT-TYPE") {
+		t.Errorf("Expected CONTENT-TYPE to match Content-Type")
+	}
+
+	// The first-seen spelling is kept
+	if got := s.ToSlice()[0]; got != "Alice@Example.com" {
+		t.Errorf("Expected Alice@Example.com, got %s", got)
+	}
+
+	// Delete matches regardless of case
+	if _, ok := s.Delete("ALICE@EXAMPLE.COM"); !ok || s.Exists("alice@example.com") {
+		t.Errorf("Expected the address to be deleted case-insensitively")
+	}
+}
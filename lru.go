@@ -0,0 +1,254 @@
+package snapset
+
+import (
+	"container/list"
+	"math/rand"
+)
+
+// LRUSet is a bounded Set that evicts the least recently used element when it is full.
+// An element counts as used when it is inserted or re-inserted by Insert, InsertMany,
+// InsertIfAbsent, InsertFromChan or Merge, found by Exists, ContainsAll, ContainsAny,
+// ExistsAll or Missing, or returned by GetRandom, GetRandomOK, MustGetRandom, GetRandomN,
+// GetRandomWithReplacement, GetRandomIndexed, GetRandomFrom or GetRandomExcept.
+// Freeze views go through the same methods.
+// Iteration, indexing, Len and the set comparisons do not count as use.
+// Recency is kept in a doubly linked list maintained through the embedded set's hooks
+// and victim policy, so all other methods behave exactly as in Set.
+// Note: LRUSet is not safe for concurrent use; even Exists updates the recency list.
+type LRUSet[T comparable] struct {
+	*Set[T]
+	recency *list.List          // elements from most to least recently used
+	nodes   map[T]*list.Element // maps elements to their node in recency
+}
+
+// NewLRU creates and returns a new LRUSet that never holds more than maxSize elements.
+// onEvict, if not nil, is called with each evicted element after it has been removed.
+// The returned set satisfies the SnapSet interface. NewLRU panics if maxSize is not positive.
+func NewLRU[T comparable](maxSize int, onEvict func(T)) *LRUSet[T] {
+	if maxSize <= 0 {
+		panic("snapset: non-positive bound")
+	}
+	l := &LRUSet[T]{
+		Set:     newSet[T](maxSize, newSource()),
+		recency: list.New(),
+		nodes:   make(map[T]*list.Element, maxSize),
+	}
+	l.Set.maxSize = maxSize
+	l.Set.onEvict = onEvict
+	l.Set.hooks = Hooks[T]{
+		OnInsert: func(element T) {
+			l.nodes[element] = l.recency.PushFront(element)
+		},
+		OnDelete: func(element T) {
+			l.recency.Remove(l.nodes[element])
+			delete(l.nodes, element)
+		},
+	}
+	l.Set.victim = func() int {
+		return l.Set.bucket[l.recency.Back().Value.(T)]
+	}
+	return l
+}
+
+// touch marks element as the most recently used one, if it is in the set.
+func (l *LRUSet[T]) touch(element T) {
+	if node, ok := l.nodes[element]; ok {
+		l.recency.MoveToFront(node)
+	}
+}
+
+// Insert adds the specified element to the set, evicting the least recently used element
+// if the set is full, and returns its index. Re-inserting an existing element marks it as used.
+func (l *LRUSet[T]) Insert(data T) int {
+	l.touch(data)
+	return l.Set.Insert(data)
+}
+
+// Exists checks if the specified element is present in the set, marking it as used if so.
+func (l *LRUSet[T]) Exists(element T) bool {
+	l.touch(element)
+	return l.Set.Exists(element)
+}
+
+// GetRandom returns a random element from the set and marks it as used.
+// It panics if the set is empty.
+func (l *LRUSet[T]) GetRandom() T {
+	element := l.Set.GetRandom()
+	l.touch(element)
+	return element
+}
+
+// GetRandomOK returns a random element from the set and true, marking it as used,
+// or the zero value of T and false if the set is empty.
+func (l *LRUSet[T]) GetRandomOK() (T, bool) {
+	element, ok := l.Set.GetRandomOK()
+	if ok {
+		l.touch(element)
+	}
+	return element, ok
+}
+
+// MustGetRandom returns a random element from the set and marks it as used,
+// panicking with a descriptive message if the set is empty.
+func (l *LRUSet[T]) MustGetRandom() T {
+	element := l.Set.MustGetRandom()
+	l.touch(element)
+	return element
+}
+
+// InsertMany adds the given elements in order, like Insert, and returns the number newly added.
+// Elements that already exist are marked as used.
+func (l *LRUSet[T]) InsertMany(data ...T) int {
+	added := 0
+	for _, element := range data {
+		if _, ok := l.InsertIfAbsent(element); ok {
+			added++
+		}
+	}
+	return added
+}
+
+// InsertIfAbsent adds the element if it does not already exist, like Set.InsertIfAbsent,
+// and marks it as used either way.
+func (l *LRUSet[T]) InsertIfAbsent(data T) (int, bool) {
+	l.touch(data)
+	return l.Set.InsertIfAbsent(data)
+}
+
+// InsertFromChan inserts every value received from ch until ch is closed, marking each as used,
+// and returns the number of elements newly added.
+func (l *LRUSet[T]) InsertFromChan(ch <-chan T) int {
+	added := 0
+	for element := range ch {
+		if _, ok := l.InsertIfAbsent(element); ok {
+			added++
+		}
+	}
+	return added
+}
+
+// Merge inserts every element of other into the set, marking each as used,
+// and returns the number of elements that were newly added.
+func (l *LRUSet[T]) Merge(other SnapSet[T]) int {
+	added := 0
+	for _, element := range other.ToSlice() {
+		if _, ok := l.InsertIfAbsent(element); ok {
+			added++
+		}
+	}
+	return added
+}
+
+// ContainsAll reports whether every given element exists in the set, like Set.ContainsAll,
+// marking the elements it finds as used.
+func (l *LRUSet[T]) ContainsAll(elems ...T) bool {
+	for _, element := range elems {
+		if !l.Exists(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny reports whether at least one given element exists in the set, like Set.ContainsAny,
+// marking the element it finds as used.
+func (l *LRUSet[T]) ContainsAny(elems ...T) bool {
+	for _, element := range elems {
+		if l.Exists(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExistsAll reports the presence of each given element, like Set.ExistsAll,
+// marking the present ones as used.
+func (l *LRUSet[T]) ExistsAll(elems []T) []bool {
+	out := make([]bool, len(elems))
+	for i, element := range elems {
+		out[i] = l.Exists(element)
+	}
+	return out
+}
+
+// Missing returns the given elements that do not exist in the set, like Set.Missing,
+// marking the present ones as used.
+func (l *LRUSet[T]) Missing(elems ...T) []T {
+	var missing []T
+	for _, element := range elems {
+		if !l.Exists(element) {
+			missing = append(missing, element)
+		}
+	}
+	return missing
+}
+
+// GetRandomN returns up to n distinct random elements, like Set.GetRandomN, and marks them as used.
+func (l *LRUSet[T]) GetRandomN(n int) []T {
+	out := l.Set.GetRandomN(n)
+	for _, element := range out {
+		l.touch(element)
+	}
+	return out
+}
+
+// GetRandomWithReplacement returns n random elements sampled with replacement,
+// like Set.GetRandomWithReplacement, and marks them as used.
+func (l *LRUSet[T]) GetRandomWithReplacement(n int) []T {
+	out := l.Set.GetRandomWithReplacement(n)
+	for _, element := range out {
+		l.touch(element)
+	}
+	return out
+}
+
+// GetRandomIndexed returns a random element and its index, like Set.GetRandomIndexed,
+// and marks the element as used.
+func (l *LRUSet[T]) GetRandomIndexed() (T, int, bool) {
+	element, idx, ok := l.Set.GetRandomIndexed()
+	if ok {
+		l.touch(element)
+	}
+	return element, idx, ok
+}
+
+// GetRandomFrom returns a random element chosen with r, like Set.GetRandomFrom,
+// and marks it as used.
+func (l *LRUSet[T]) GetRandomFrom(r *rand.Rand) (T, bool) {
+	element, ok := l.Set.GetRandomFrom(r)
+	if ok {
+		l.touch(element)
+	}
+	return element, ok
+}
+
+// GetRandomExcept returns a random element not in exclude, like Set.GetRandomExcept,
+// and marks it as used.
+func (l *LRUSet[T]) GetRandomExcept(exclude SnapSet[T]) (T, bool) {
+	element, ok := l.Set.GetRandomExcept(exclude)
+	if ok {
+		l.touch(element)
+	}
+	return element, ok
+}
+
+// Freeze returns a read-only view of the set whose lookups and random picks mark elements as used.
+func (l *LRUSet[T]) Freeze() ReadOnlySet[T] {
+	return frozenSet[T]{set: l}
+}
+
+// CloneCOW returns an eager deep copy of the set, like Clone.
+// The recency list cannot be shared between sets, so the copy is never deferred.
+func (l *LRUSet[T]) CloneCOW() SnapSet[T] {
+	return l.Clone()
+}
+
+// Clone returns an independent deep copy of the set with the same bound and recency order.
+// The eviction callback is not carried over.
+func (l *LRUSet[T]) Clone() SnapSet[T] {
+	c := NewLRU[T](l.maxSize, nil)
+	for node := l.recency.Back(); node != nil; node = node.Prev() {
+		c.Set.Insert(node.Value.(T))
+	}
+	return c
+}
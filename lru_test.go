@@ -0,0 +1,132 @@
+package snapset_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestNewLRU checks that the least recently used element is evicted.
+func TestNewLRU(t *testing.T) {
+	var evicted []int
+	s := snapset.NewLRU(3, func(val int) { evicted = append(evicted, val) })
+
+	// Fill the set to its bound
+	s.InsertMany(1, 2, 3)
+
+	// Use 1, so 2 becomes the least recently used
+	if !s.Exists(1) {
+		t.Errorf("Expected 1 to exist")
+	}
+
+	s.Insert(4)
+	if len(evicted) != 1 || evicted[0] != 2 {
+		t.Errorf("Expected 2 to be evicted, got %v", evicted)
+	}
+
+	// Re-inserting 3 marks it as used, so 1 is next
+	s.Insert(3)
+	s.Insert(5)
+	if len(evicted) != 2 || evicted[1] != 1 {
+		t.Errorf("Expected 1 to be evicted, got %v", evicted)
+	}
+
+	// Deleted elements are no longer candidates
+	s.Delete(4)
+	s.Insert(6)
+	if len(evicted) != 2 || s.Len() != 3 {
+		t.Errorf("Expected no further evictions and length 3, got %v and %d", evicted, s.Len())
+	}
+
+	// Clones keep the recency order: 3 is now the least recently used
+	c := s.Clone()
+	c.Insert(7)
+	if c.Exists(3) || !c.ContainsAll(5, 6, 7) {
+		t.Errorf("Expected clone {5, 6, 7}, got %v", c)
+	}
+}
+
+// TestLRURemoveAllSelf checks that removing an LRUSet from itself empties it.
+func TestLRURemoveAllSelf(t *testing.T) {
+	l := snapset.NewLRU[int](10, nil)
+	for i := 0; i < 6; i++ {
+		l.Insert(i)
+	}
+
+	if removed := l.RemoveAll(l); removed != 6 || l.Len() != 0 {
+		t.Errorf("Expected 6 removed elements and an empty set, got %d and %d", removed, l.Len())
+	}
+}
+
+// TestLRUCloneCOW checks that a copy-on-write clone is still an LRUSet.
+func TestLRUCloneCOW(t *testing.T) {
+	s := snapset.NewLRU[int](3, nil)
+	s.InsertMany(1, 2, 3)
+	s.Exists(1) // 2 becomes the least recently used
+
+	c, ok := s.CloneCOW().(*snapset.LRUSet[int])
+	if !ok {
+		t.Fatalf("Expected CloneCOW to return an LRUSet")
+	}
+
+	c.Insert(4)
+	if c.Exists(2) || !c.ContainsAll(1, 3, 4) {
+		t.Errorf("Expected the clone to evict 2, got %v", c)
+	}
+	assertElements(t, s, 1, 2, 3)
+}
+
+// TestLRUTouchingMethods checks that every accessor that counts as use updates the recency order.
+func TestLRUTouchingMethods(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	cases := map[string]func(l *snapset.LRUSet[int]) int{
+		"InsertMany":     func(l *snapset.LRUSet[int]) int { l.InsertMany(1); return 1 },
+		"InsertIfAbsent": func(l *snapset.LRUSet[int]) int { l.InsertIfAbsent(1); return 1 },
+		"InsertFromChan": func(l *snapset.LRUSet[int]) int {
+			ch := make(chan int, 1)
+			ch <- 1
+			close(ch)
+			l.InsertFromChan(ch)
+			return 1
+		},
+		"Merge":       func(l *snapset.LRUSet[int]) int { l.Merge(fromSlice(1)); return 1 },
+		"ContainsAll": func(l *snapset.LRUSet[int]) int { l.ContainsAll(1); return 1 },
+		"ContainsAny": func(l *snapset.LRUSet[int]) int { l.ContainsAny(1); return 1 },
+		"ExistsAll":   func(l *snapset.LRUSet[int]) int { l.ExistsAll([]int{1}); return 1 },
+		"Missing":     func(l *snapset.LRUSet[int]) int { l.Missing(1); return 1 },
+		"Freeze":      func(l *snapset.LRUSet[int]) int { l.Freeze().Exists(1); return 1 },
+		"GetRandomN":  func(l *snapset.LRUSet[int]) int { return l.GetRandomN(1)[0] },
+		"GetRandomWithReplacement": func(l *snapset.LRUSet[int]) int {
+			return l.GetRandomWithReplacement(1)[0]
+		},
+		"GetRandomIndexed": func(l *snapset.LRUSet[int]) int {
+			element, _, _ := l.GetRandomIndexed()
+			return element
+		},
+		"GetRandomFrom": func(l *snapset.LRUSet[int]) int {
+			element, _ := l.GetRandomFrom(r)
+			return element
+		},
+		"GetRandomExcept": func(l *snapset.LRUSet[int]) int {
+			element, _ := l.GetRandomExcept(fromSlice(2, 3))
+			return element
+		},
+	}
+
+	for name, use := range cases {
+		// Random picks are repeated so that the least recently used element 1 is picked at least once
+		for i := 0; i < 20; i++ {
+			var evicted []int
+			l := snapset.NewLRU(3, func(val int) { evicted = append(evicted, val) })
+			l.InsertMany(1, 2, 3)
+
+			used := use(l)
+			l.Insert(4)
+			if len(evicted) != 1 || evicted[0] == used {
+				t.Errorf("%s: expected %d to be marked as used, got evictions %v", name, used, evicted)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package snapset
+
+import (
+	"cmp"
+	"errors"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// maxPowerSetElements is the largest set PowerSet accepts: its 2^16 subsets take about 40 MB
+// and 40 ms to build, and every further element doubles both.
+const maxPowerSetElements = 16
+
+// ErrPowerSetTooLarge is returned by PowerSet when the input has more than 16 elements.
+var ErrPowerSetTooLarge = errors.New("snapset: power set input has more than 16 elements")
+
+// Union returns a new set containing every element present in either a or b.
+// The result is pre-sized to the sum of their lengths and is a plain, unbounded set,
+// so elements present in both sets appear only once and a size bound on either input
+// cannot evict any of them. Neither input is modified.
+func Union[T comparable](a, b SnapSet[T]) SnapSet[T] {
+	out := newSet[T](a.Len()+b.Len(), newSource())
+	out.Grow(a.Len() + b.Len())
+	out.InsertMany(a.ToSlice()...)
+	out.InsertMany(b.ToSlice()...)
+	return out
+}
+
+// UnionAll returns a new set containing every element present in any of sets.
+// The result is pre-sized to the sum of their lengths and filled starting from the largest set,
+// which avoids the intermediate sets of chaining Union pairwise. None of the inputs is modified,
+// and calling it without arguments returns an empty set.
+func UnionAll[T comparable](sets ...SnapSet[T]) SnapSet[T] {
+	total, largest := 0, -1
+	for i, s := range sets {
+		total += s.Len()
+		if largest < 0 || s.Len() > sets[largest].Len() {
+			largest = i
+		}
+	}
+
+	out := newSet[T](total, newSource())
+	if largest < 0 {
+		return out
+	}
+
+	out.Grow(total)
+	sets[largest].ForEach(func(element T) bool {
+		out.Insert(element)
+		return true
+	})
+	for i, s := range sets {
+		if i == largest {
+			continue
+		}
+		s.ForEach(func(element T) bool {
+			out.Insert(element)
+			return true
+		})
+	}
+	return out
+}
+
+// Intersection returns a new set containing the elements present in both a and b.
+// It iterates over the smaller set and checks membership in the larger one.
+// If the sets share no elements, the result is an empty set. Neither input is modified.
+func Intersection[T comparable](a, b SnapSet[T]) SnapSet[T] {
+	if a.Len() > b.Len() {
+		a, b = b, a
+	}
+
+	out := New[T](a.Len())
+	for _, element := range a.ToSlice() {
+		if b.Exists(element) {
+			out.Insert(element)
+		}
+	}
+	return out
+}
+
+// IntersectionAll returns a new set containing the elements present in every one of sets.
+// It starts from the elements of the smallest set and narrows them against the others
+// in order of increasing size, stopping early once no candidates remain.
+// None of the inputs is modified, and calling it without arguments returns an empty set.
+func IntersectionAll[T comparable](sets ...SnapSet[T]) SnapSet[T] {
+	if len(sets) == 0 {
+		return New[T](0)
+	}
+
+	ordered := slices.Clone(sets)
+	slices.SortFunc(ordered, func(a, b SnapSet[T]) int {
+		return cmp.Compare(a.Len(), b.Len())
+	})
+
+	candidates := ordered[0].ToSlice()
+	for _, s := range ordered[1:] {
+		if len(candidates) == 0 {
+			break
+		}
+		candidates = slices.DeleteFunc(candidates, func(element T) bool {
+			return !s.Exists(element)
+		})
+	}
+	return NewFromSlice(candidates)
+}
+
+// Difference returns a new set containing the elements of a that are not present in b.
+// The operation is asymmetric: Difference(a, b) is generally not the same as Difference(b, a).
+// Neither input is modified.
+func Difference[T comparable](a, b SnapSet[T]) SnapSet[T] {
+	out := New[T](a.Len())
+	for _, element := range a.ToSlice() {
+		if !b.Exists(element) {
+			out.Insert(element)
+		}
+	}
+	return out
+}
+
+// SymmetricDifference returns a new set containing the elements that are in exactly one of a and b.
+// It scans each input once against the other, without building the full union first.
+// Neither input is modified.
+func SymmetricDifference[T comparable](a, b SnapSet[T]) SnapSet[T] {
+	out := New[T](a.Len() + b.Len())
+	for _, element := range a.ToSlice() {
+		if !b.Exists(element) {
+			out.Insert(element)
+		}
+	}
+	for _, element := range b.ToSlice() {
+		if !a.Exists(element) {
+			out.Insert(element)
+		}
+	}
+	return out
+}
+
+// Map returns a new set containing the result of applying fn to every element of s.
+// Elements that map to the same value collapse into a single element of the result.
+// It is a function rather than a method because Go methods cannot declare their own type parameters.
+func Map[T, U comparable](s SnapSet[T], fn func(T) U) SnapSet[U] {
+	out := New[U](s.Len())
+	s.ForEach(func(element T) bool {
+		out.Insert(fn(element))
+		return true
+	})
+	return out
+}
+
+// Reduce folds the elements of s into a single value: starting from init, it replaces
+// the accumulator with fn(accumulator, element) for every element and returns the result.
+// Elements are visited in no particular order, so fn should not depend on it.
+// Like Map, it is a function because the accumulator type differs from the element type.
+func Reduce[T comparable, A any](s SnapSet[T], init A, fn func(A, T) A) A {
+	acc := init
+	s.ForEach(func(element T) bool {
+		acc = fn(acc, element)
+		return true
+	})
+	return acc
+}
+
+// PowerSet returns every subset of s, from the empty set to a copy of s itself.
+// The cost is exponential: a set of n elements has 2^n subsets, holding n*2^(n-1) elements
+// in total, so PowerSet refuses inputs of more than 16 elements with ErrPowerSetTooLarge.
+// Subset i contains the elements of s.ToSlice() whose positions are set in the bits of i.
+// The subsets draw from one shared, mutex-guarded random source, so reseeding one reseeds all.
+func PowerSet[T comparable](s SnapSet[T]) ([]SnapSet[T], error) {
+	elements := s.ToSlice()
+	if len(elements) > maxPowerSetElements {
+		return nil, ErrPowerSetTooLarge
+	}
+
+	// Seeding a source per subset would dominate the cost, so all subsets share one
+	src := &lockedSource{src: newSource()}
+	out := make([]SnapSet[T], 1<<len(elements))
+	for mask := range out {
+		subset := newSetRand[T](bits.OnesCount(uint(mask)), rand.New(src))
+		for i, element := range elements {
+			if mask&(1<<i) != 0 {
+				subset.Insert(element)
+			}
+		}
+		out[mask] = subset
+	}
+	return out, nil
+}
+
+// Pair holds one combination of elements produced by Product.
+// It is comparable, so pairs can themselves be stored in a set.
+type Pair[A, B comparable] struct {
+	First  A
+	Second B
+}
+
+// Product returns the Cartesian product of a and b: one Pair for every combination
+// of an element of a with an element of b, so the result has a.Len()*b.Len() pairs.
+// Since both inputs are sets, no pair appears twice. The pairs are grouped by their
+// first element, in the iteration order of a, and within a group follow the iteration order of b.
+func Product[A, B comparable](a SnapSet[A], b SnapSet[B]) []Pair[A, B] {
+	seconds := b.ToSlice()
+	out := make([]Pair[A, B], 0, a.Len()*len(seconds))
+	a.ForEach(func(first A) bool {
+		for _, second := range seconds {
+			out = append(out, Pair[A, B]{First: first, Second: second})
+		}
+		return true
+	})
+	return out
+}
+
+// Jaccard returns the Jaccard similarity of a and b: the size of their intersection
+// divided by the size of their union. Two empty sets are considered identical and yield 1.0.
+// The sizes are counted directly, without materializing the intersection or union.
+func Jaccard[T comparable](a, b SnapSet[T]) float64 {
+	shared := a.IntersectionCount(b)
+	union := a.Len() + b.Len() - shared
+	if union == 0 {
+		return 1.0
+	}
+	return float64(shared) / float64(union)
+}
+
+// Sorted returns the elements of s sorted in ascending order.
+// It takes the cmp.Ordered constraint separately from the set, since a set only
+// requires its elements to be comparable.
+func Sorted[T cmp.Ordered](s SnapSet[T]) []T {
+	out := s.ToSlice()
+	slices.Sort(out)
+	return out
+}
+
+// Min returns the smallest element of s and true, or the zero value and false if s is empty.
+// It scans the elements once, without sorting.
+func Min[T cmp.Ordered](s SnapSet[T]) (T, bool) {
+	return extreme(s, func(a, b T) bool { return a < b })
+}
+
+// Max returns the largest element of s and true, or the zero value and false if s is empty.
+// It scans the elements once, without sorting.
+func Max[T cmp.Ordered](s SnapSet[T]) (T, bool) {
+	return extreme(s, func(a, b T) bool { return a > b })
+}
+
+// extreme returns the element of s that is better than every other element according to better.
+func extreme[T cmp.Ordered](s SnapSet[T], better func(a, b T) bool) (T, bool) {
+	var best T
+	found := false
+	s.ForEach(func(element T) bool {
+		if !found || better(element, best) {
+			best = element
+			found = true
+		}
+		return true
+	})
+	return best, found
+}
+
+// lockedSource is a rand.Source guarded by a mutex, so that many generators can share it
+// safely without each paying for a seeded source of its own.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+// Int63 returns a non-negative random 63-bit integer from the shared source.
+func (l *lockedSource) Int63() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.src.Int63()
+}
+
+// Seed reseeds the shared source.
+func (l *lockedSource) Seed(seed int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.src.Seed(seed)
+}
@@ -0,0 +1,269 @@
+package snapset_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// fromSlice builds a set containing the given elements.
+func fromSlice[T comparable](elements ...T) snapset.SnapSet[T] {
+	s := snapset.New[T](snapset.DefaultBucketSize)
+	for _, element := range elements {
+		s.Insert(element)
+	}
+	return s
+}
+
+// assertElements fails the test unless s contains exactly the expected elements.
+func assertElements[T comparable](t *testing.T, s snapset.SnapSet[T], expected ...T) {
+	t.Helper()
+
+	if s.Len() != len(expected) {
+		t.Errorf("Expected %d elements, got %d: %v", len(expected), s.Len(), s.ToSlice())
+	}
+	for _, element := range expected {
+		if !s.Exists(element) {
+			t.Errorf("Element %v should exist", element)
+		}
+	}
+}
+
+// TestUnion checks the Union function.
+func TestUnion(t *testing.T) {
+	a := fromSlice(1, 2, 3)
+	b := fromSlice(3, 4)
+
+	u := snapset.Union(a, b)
+	assertElements(t, u, 1, 2, 3, 4)
+
+	// Inputs must be left unchanged
+	assertElements(t, a, 1, 2, 3)
+	assertElements(t, b, 3, 4)
+
+	// Union with an empty set
+	assertElements(t, snapset.Union(snapset.New[int](0), b), 3, 4)
+
+	// A bounded input does not bound the result
+	bounded := snapset.NewBounded[int](3, nil)
+	bounded.InsertMany(1, 2, 3)
+	assertElements(t, snapset.Union(bounded, fromSlice(4, 5)), 1, 2, 3, 4, 5)
+}
+
+// TestUnionAll checks the union of many sets.
+func TestUnionAll(t *testing.T) {
+	result := snapset.UnionAll(fromSlice(1, 2), fromSlice(2, 3, 4, 5), fromSlice(5, 6), fromSlice[int]())
+	assertElements(t, result, 1, 2, 3, 4, 5, 6)
+
+	// No sets yields an empty set
+	if result = snapset.UnionAll[int](); result.Len() != 0 {
+		t.Errorf("Expected an empty set, got %v", result)
+	}
+}
+
+// TestIntersection checks the Intersection function.
+func TestIntersection(t *testing.T) {
+	a := fromSlice(1, 2, 3)
+	b := fromSlice(2, 3, 4, 5)
+
+	assertElements(t, snapset.Intersection(a, b), 2, 3)
+	assertElements(t, snapset.Intersection(b, a), 2, 3)
+
+	// Disjoint sets yield a valid empty set
+	empty := snapset.Intersection(a, fromSlice(7, 8))
+	if empty == nil || empty.Len() != 0 {
+		t.Errorf("Expected an empty set for disjoint inputs")
+	}
+}
+
+// TestIntersectionAll checks the intersection of many sets.
+func TestIntersectionAll(t *testing.T) {
+	result := snapset.IntersectionAll(fromSlice(1, 2, 3, 4, 5), fromSlice(2, 3, 4), fromSlice(3, 4, 9))
+	assertElements(t, result, 3, 4)
+
+	// Disjoint inputs yield an empty set
+	result = snapset.IntersectionAll(fromSlice(1, 2), fromSlice(3), fromSlice(1, 3))
+	if result.Len() != 0 {
+		t.Errorf("Expected an empty set, got %v", result)
+	}
+
+	// No sets yields an empty set
+	if result = snapset.IntersectionAll[int](); result.Len() != 0 {
+		t.Errorf("Expected an empty set, got %v", result)
+	}
+}
+
+// TestDifference checks the Difference function.
+func TestDifference(t *testing.T) {
+	a := fromSlice(1, 2, 3)
+	b := fromSlice(2, 3, 4)
+
+	// Difference is asymmetric
+	assertElements(t, snapset.Difference(a, b), 1)
+	assertElements(t, snapset.Difference(b, a), 4)
+
+	// Inputs must be left unchanged
+	assertElements(t, a, 1, 2, 3)
+	assertElements(t, b, 2, 3, 4)
+}
+
+// TestSymmetricDifference checks the SymmetricDifference function.
+func TestSymmetricDifference(t *testing.T) {
+	a := fromSlice(1, 2, 3)
+	b := fromSlice(2, 3, 4)
+
+	assertElements(t, snapset.SymmetricDifference(a, b), 1, 4)
+	assertElements(t, snapset.SymmetricDifference(b, a), 1, 4)
+
+	// Identical sets have an empty symmetric difference
+	assertElements(t, snapset.SymmetricDifference(a, a.Clone()))
+}
+
+// TestMap checks the Map function.
+func TestMap(t *testing.T) {
+	type user struct {
+		id   int
+		name string
+	}
+
+	users := fromSlice(user{1, "ann"}, user{2, "bob"}, user{1, "ann (alias)"})
+	ids := snapset.Map(users, func(u user) int { return u.id })
+
+	// Colliding values collapse into one element
+	assertElements(t, ids, 1, 2)
+
+	// The input is left unchanged
+	if users.Len() != 3 {
+		t.Errorf("Expected input length 3, got %d", users.Len())
+	}
+}
+
+// TestReduce checks folding a set into a value of another type.
+func TestReduce(t *testing.T) {
+	s := fromSlice(1, 2, 3, 4)
+
+	sum := snapset.Reduce(s, 0.5, func(acc float64, val int) float64 { return acc + float64(val) })
+	if sum != 10.5 {
+		t.Errorf("Expected 10.5, got %f", sum)
+	}
+
+	// An empty set returns the initial value
+	empty := snapset.New[int](snapset.DefaultBucketSize)
+	if got := snapset.Reduce(empty, "init", func(acc string, _ int) string { return "" }); got != "init" {
+		t.Errorf("Expected init, got %s", got)
+	}
+}
+
+// TestPowerSet checks that every subset is produced exactly once.
+func TestPowerSet(t *testing.T) {
+	subsets, err := snapset.PowerSet(fromSlice("a", "b", "c"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(subsets) != 8 {
+		t.Fatalf("Expected 8 subsets, got %d", len(subsets))
+	}
+
+	// Count subsets by size and check they are distinct
+	sizes := make(map[int]int)
+	for i, a := range subsets {
+		sizes[a.Len()]++
+		for _, b := range subsets[i+1:] {
+			if a.Equal(b) {
+				t.Errorf("Duplicate subset %v", a)
+			}
+		}
+	}
+	if sizes[0] != 1 || sizes[1] != 3 || sizes[2] != 3 || sizes[3] != 1 {
+		t.Errorf("Expected subset sizes 1, 3, 3, 1, got %v", sizes)
+	}
+
+	// Large inputs are rejected
+	large := snapset.New[int](snapset.DefaultBucketSize)
+	for i := 0; i < 17; i++ {
+		large.Insert(i)
+	}
+	if _, err = snapset.PowerSet(large); !errors.Is(err, snapset.ErrPowerSetTooLarge) {
+		t.Errorf("Expected ErrPowerSetTooLarge, got %v", err)
+	}
+}
+
+// TestProduct checks that every combination appears exactly once.
+func TestProduct(t *testing.T) {
+	pairs := snapset.Product(fromSlice("linux", "darwin"), fromSlice(386, 64, 32))
+	if len(pairs) != 6 {
+		t.Fatalf("Expected 6 pairs, got %d", len(pairs))
+	}
+
+	// Pairs are comparable and distinct
+	set := snapset.NewFromSlice(pairs)
+	if set.Len() != 6 || !set.Exists(snapset.Pair[string, int]{First: "darwin", Second: 64}) {
+		t.Errorf("Expected 6 distinct pairs including {darwin 64}, got %v", set)
+	}
+
+	// An empty input yields no pairs
+	if pairs = snapset.Product(fromSlice("linux"), snapset.New[int](0)); len(pairs) != 0 {
+		t.Errorf("Expected no pairs, got %v", pairs)
+	}
+}
+
+// TestJaccard checks the Jaccard function.
+func TestJaccard(t *testing.T) {
+	a := fromSlice(1, 2, 3, 4)
+	b := fromSlice(3, 4, 5, 6)
+
+	// Two shared elements out of six distinct ones
+	if got := snapset.Jaccard(a, b); got != 2.0/6.0 {
+		t.Errorf("Expected %f, got %f", 2.0/6.0, got)
+	}
+
+	if got := snapset.Jaccard(a, a.Clone()); got != 1.0 {
+		t.Errorf("Expected 1.0 for identical sets, got %f", got)
+	}
+
+	if got := snapset.Jaccard(a, fromSlice(7)); got != 0.0 {
+		t.Errorf("Expected 0.0 for disjoint sets, got %f", got)
+	}
+
+	if got := snapset.Jaccard(snapset.New[int](0), snapset.New[int](0)); got != 1.0 {
+		t.Errorf("Expected 1.0 for two empty sets, got %f", got)
+	}
+}
+
+// TestSorted checks the Sorted function.
+func TestSorted(t *testing.T) {
+	s := fromSlice("pear", "apple", "fig")
+	s.Delete("pear")
+	s.Insert("banana")
+
+	expected := []string{"apple", "banana", "fig"}
+	if got := snapset.Sorted(s); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := snapset.Sorted(snapset.New[int](0)); len(got) != 0 {
+		t.Errorf("Expected no elements, got %v", got)
+	}
+}
+
+// TestMinMax checks the Min and Max functions.
+func TestMinMax(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	if _, ok := snapset.Min(s); ok {
+		t.Errorf("Expected no minimum for an empty set")
+	}
+	if _, ok := snapset.Max(s); ok {
+		t.Errorf("Expected no maximum for an empty set")
+	}
+
+	s.InsertMany(42, -7, 13, 99, 0)
+	if val, ok := snapset.Min(s); !ok || val != -7 {
+		t.Errorf("Expected (-7, true), got (%d, %t)", val, ok)
+	}
+	if val, ok := snapset.Max(s); !ok || val != 99 {
+		t.Errorf("Expected (99, true), got (%d, %t)", val, ok)
+	}
+}
@@ -0,0 +1,153 @@
+package snapset
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"iter"
+)
+
+// OrderedSet is a Set that remembers the order in which elements were inserted.
+// Membership checks and random selection work exactly as in Set and stay O(1),
+// while ToSlice, ForEach, All, CopyInto, String, Stream, IterateSafe, Freeze, Snapshot,
+// Checkpoint and the JSON, text and gob encodings use insertion order
+// instead of the scrambled order left behind by swap-deletes.
+// The order is kept in a doubly linked list maintained through the embedded set's hooks,
+// so every method that inserts or removes elements keeps it up to date.
+// Re-inserting an existing element does not move it; Replace moves the new element to the end.
+//
+// The exceptions are the methods that expose the embedded set's internal list:
+// At, IndexOf and AllIndexed use list indices, which do not follow insertion order,
+// and Shuffle permutes that list without changing the insertion order.
+// Filter, Partition and the package-level set operations return plain, unordered sets.
+type OrderedSet[T comparable] struct {
+	*Set[T]
+	order *list.List          // elements in insertion order
+	nodes map[T]*list.Element // maps elements to their node in order
+}
+
+// NewOrdered creates and returns a new, empty OrderedSet with the specified initial size.
+// The returned set satisfies the SnapSet interface.
+func NewOrdered[T comparable](size int) *OrderedSet[T] {
+	o := &OrderedSet[T]{
+		Set:   newSet[T](size, newSource()),
+		order: list.New(),
+		nodes: make(map[T]*list.Element, max(size, 0)),
+	}
+	o.Set.hooks = Hooks[T]{
+		OnInsert: func(element T) {
+			o.nodes[element] = o.order.PushBack(element)
+		},
+		OnDelete: func(element T) {
+			o.order.Remove(o.nodes[element])
+			delete(o.nodes, element)
+		},
+	}
+	return o
+}
+
+// ToSlice returns a copy of all elements in insertion order.
+func (o *OrderedSet[T]) ToSlice() []T {
+	out := make([]T, 0, o.Len())
+	for node := o.order.Front(); node != nil; node = node.Next() {
+		out = append(out, node.Value.(T))
+	}
+	return out
+}
+
+// ForEach calls fn for each element in insertion order, stopping early if fn returns false.
+// Mutating the set from within fn is undefined behavior.
+func (o *OrderedSet[T]) ForEach(fn func(T) bool) {
+	for node := o.order.Front(); node != nil; node = node.Next() {
+		if !fn(node.Value.(T)) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the elements in insertion order.
+// Modifying the set during iteration is unsupported.
+func (o *OrderedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		o.ForEach(yield)
+	}
+}
+
+// CopyInto copies as many elements as fit into dst, in insertion order, and returns the number copied.
+func (o *OrderedSet[T]) CopyInto(dst []T) int {
+	n := 0
+	for node := o.order.Front(); node != nil && n < len(dst); node = node.Next() {
+		dst[n] = node.Value.(T)
+		n++
+	}
+	return n
+}
+
+// Clone returns an independent deep copy of the set that preserves the insertion order.
+func (o *OrderedSet[T]) Clone() SnapSet[T] {
+	c := NewOrdered[T](o.Len())
+	c.InsertMany(o.ToSlice()...)
+	return c
+}
+
+// CloneCOW returns an eager deep copy of the set that preserves the insertion order, like Clone.
+// The order list cannot be shared between sets, so the copy is never deferred.
+func (o *OrderedSet[T]) CloneCOW() SnapSet[T] {
+	return o.Clone()
+}
+
+// Snapshot captures the current contents of the set in insertion order.
+// Restoring it re-appends the elements removed since, in their original relative order.
+func (o *OrderedSet[T]) Snapshot() Snapshot[T] {
+	return Snapshot[T]{elements: o.ToSlice()}
+}
+
+// String formats the set in insertion order, in the same way as Set.String.
+func (o *OrderedSet[T]) String() string {
+	return formatSet(o.Len(), o.All())
+}
+
+// Stream sends the elements over a new channel in insertion order,
+// in the same way as Set.Stream.
+func (o *OrderedSet[T]) Stream(ctx context.Context) <-chan T {
+	return stream(ctx, o.ToSlice())
+}
+
+// IterateSafe calls fn for each element in insertion order and deletes the elements
+// for which fn returns false, in the same way as Set.IterateSafe.
+func (o *OrderedSet[T]) IterateSafe(fn func(T) (keep bool)) {
+	for _, element := range o.ToSlice() {
+		if !fn(element) {
+			o.Delete(element)
+		}
+	}
+}
+
+// Freeze returns a read-only view of the set that iterates in insertion order.
+func (o *OrderedSet[T]) Freeze() ReadOnlySet[T] {
+	return frozenSet[T]{set: o}
+}
+
+// Checkpoint saves the current contents of the set in insertion order under the given name,
+// so that Rollback restores the elements removed since in their original relative order.
+func (o *OrderedSet[T]) Checkpoint(name string) {
+	if o.checkpoints == nil {
+		o.checkpoints = make(map[string]Snapshot[T])
+	}
+	o.checkpoints[name] = o.Snapshot()
+}
+
+// MarshalJSON encodes the set as a JSON array in insertion order.
+func (o *OrderedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.ToSlice())
+}
+
+// MarshalText encodes the set as a comma-separated list in insertion order.
+func (o *OrderedSet[T]) MarshalText() ([]byte, error) {
+	return marshalText(o.ToSlice())
+}
+
+// GobEncode encodes the elements of the set in insertion order.
+func (o *OrderedSet[T]) GobEncode() ([]byte, error) {
+	return gobEncode(o.ToSlice())
+}
@@ -0,0 +1,150 @@
+package snapset_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestOrderedSet checks that iteration follows insertion order.
+func TestOrderedSet(t *testing.T) {
+	var s snapset.SnapSet[string] = snapset.NewOrdered[string](snapset.DefaultBucketSize)
+
+	s.InsertMany("a", "b", "c", "d", "e")
+	s.Delete("b")
+	s.Insert("f")
+	s.Insert("a") // Re-inserting keeps the original position
+
+	expected := []string{"a", "c", "d", "e", "f"}
+	if got := s.ToSlice(); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := slices.Collect(s.All()); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v from All, got %v", expected, got)
+	}
+
+	if got := fmt.Sprint(s); got != "snapset{a, c, d, e, f}" {
+		t.Errorf("Expected snapset{a, c, d, e, f}, got %s", got)
+	}
+
+	// Removals through any method keep the order consistent
+	s.RemoveIf(func(val string) bool { return val == "d" })
+	popped, _ := s.Pop()
+	expected = slices.DeleteFunc(expected, func(val string) bool { return val == "d" || val == popped })
+	if got := s.ToSlice(); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	// Random selection still works
+	if val, ok := s.GetRandomOK(); !ok || !s.Exists(val) {
+		t.Errorf("Expected a random element, got (%q, %t)", val, ok)
+	}
+
+	// Clones preserve the order
+	if got := s.Clone().ToSlice(); !slices.Equal(got, expected) {
+		t.Errorf("Expected clone order %v, got %v", expected, got)
+	}
+}
+
+// TestOrderedSetDeterministic checks that the order depends only on the insertion sequence.
+func TestOrderedSetDeterministic(t *testing.T) {
+	build := func(deletes ...int) []int {
+		s := snapset.NewOrdered[int](snapset.DefaultBucketSize)
+		for i := 0; i < 10; i++ {
+			s.Insert(i)
+		}
+		s.DeleteMany(deletes...)
+		return s.ToSlice()
+	}
+
+	// The same deletions applied in a different order leave the same sequence
+	a := build(7, 2, 5, 0)
+	b := build(0, 5, 2, 7)
+	if !slices.Equal(a, b) || !slices.Equal(a, []int{1, 3, 4, 6, 8, 9}) {
+		t.Errorf("Expected [1 3 4 6 8 9] twice, got %v and %v", a, b)
+	}
+}
+
+// TestOrderedSetRemoveAllSelf checks that removing an OrderedSet from itself empties it.
+func TestOrderedSetRemoveAllSelf(t *testing.T) {
+	o := snapset.NewOrdered[int](snapset.DefaultBucketSize)
+	for i := 0; i < 6; i++ {
+		o.Insert(i)
+	}
+
+	if removed := o.RemoveAll(o); removed != 6 || o.Len() != 0 {
+		t.Errorf("Expected 6 removed elements and an empty set, got %d and %d", removed, o.Len())
+	}
+}
+
+// TestOrderedSetCloneCOW checks that a copy-on-write clone keeps the insertion order.
+func TestOrderedSetCloneCOW(t *testing.T) {
+	o := snapset.NewOrdered[int](snapset.DefaultBucketSize)
+	o.InsertMany(3, 1, 2)
+	o.Delete(3)
+
+	c, ok := o.CloneCOW().(*snapset.OrderedSet[int])
+	if !ok {
+		t.Fatalf("Expected CloneCOW to return an OrderedSet")
+	}
+
+	c.Insert(4)
+	if got := c.ToSlice(); !slices.Equal(got, []int{1, 2, 4}) {
+		t.Errorf("Expected [1 2 4], got %v", got)
+	}
+	if got := o.ToSlice(); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Expected the original to stay [1 2], got %v", got)
+	}
+}
+
+// TestOrderedSetPromotedOrder checks that encodings, views and checkpoints follow insertion order.
+func TestOrderedSetPromotedOrder(t *testing.T) {
+	s := snapset.NewOrdered[int](snapset.DefaultBucketSize)
+	s.InsertMany(0, 1, 2, 3, 4, 5)
+	s.Delete(0)
+	want := []int{1, 2, 3, 4, 5}
+
+	data, _ := s.MarshalJSON()
+	if string(data) != "[1,2,3,4,5]" {
+		t.Errorf("Expected JSON [1,2,3,4,5], got %s", data)
+	}
+	text, _ := s.MarshalText()
+	if string(text) != "1,2,3,4,5" {
+		t.Errorf("Expected text 1,2,3,4,5, got %s", text)
+	}
+	gobData, _ := s.GobEncode()
+	decoded := snapset.NewOrdered[int](snapset.DefaultBucketSize)
+	if err := decoded.GobDecode(gobData); err != nil || !slices.Equal(decoded.ToSlice(), want) {
+		t.Errorf("Expected gob round trip %v, got %v (%v)", want, decoded.ToSlice(), err)
+	}
+	if got := slices.Collect(s.Freeze().All()); !slices.Equal(got, want) {
+		t.Errorf("Expected frozen order %v, got %v", want, got)
+	}
+	var streamed []int
+	for element := range s.Stream(context.Background()) {
+		streamed = append(streamed, element)
+	}
+	if !slices.Equal(streamed, want) {
+		t.Errorf("Expected streamed order %v, got %v", want, streamed)
+	}
+	var visited []int
+	s.IterateSafe(func(element int) bool {
+		visited = append(visited, element)
+		return element != 3
+	})
+	if !slices.Equal(visited, want) || s.Exists(3) {
+		t.Errorf("Expected IterateSafe to visit %v and drop 3, got %v", want, visited)
+	}
+
+	s.Checkpoint("before")
+	s.Delete(1)
+	s.Delete(4)
+	s.Rollback("before")
+	if got := s.ToSlice(); !slices.Equal(got, []int{2, 5, 1, 4}) {
+		t.Errorf("Expected rollback order [2 5 1 4], got %v", got)
+	}
+}
@@ -0,0 +1,136 @@
+package snapset
+
+import (
+	"iter"
+	"math/rand"
+	"sync"
+)
+
+// PooledSet is a set for large element types that stores each element behind a pointer.
+// Growing the list then copies pointers instead of whole elements, which matters when T is
+// a struct of hundreds of bytes. The boxes holding deleted elements are returned to a sync.Pool
+// and reused by later inserts, so churn does not allocate a new box per element.
+//
+// The pointers never leave the set: GetRandom, ToSlice and All return copies of the elements.
+// Modifying a returned value does not affect the set, and since boxes are reused,
+// holding on to a returned value is always safe. The bucket map is still keyed by value,
+// so each element is stored twice; only the cost of growing the list is reduced.
+// Note: PooledSet is not safe for concurrent use.
+type PooledSet[T comparable] struct {
+	bucket map[T]int  // maps elements to the indices of their boxes in the list
+	list   []*T       // stores pointers to the elements
+	pool   sync.Pool  // recycles the boxes of deleted elements
+	rand   *rand.Rand // random number generator for GetRandom
+}
+
+// NewPooled creates and returns a new, empty PooledSet with the specified initial size.
+func NewPooled[T comparable](size int) *PooledSet[T] {
+	p := &PooledSet[T]{
+		bucket: make(map[T]int, max(size, 0)),
+		rand:   rand.New(newSource()),
+	}
+	p.pool.New = func() any { return new(T) }
+	return p
+}
+
+// Insert adds the specified element to the set and returns its index.
+// If the element already exists, the set is left unchanged and its index is returned.
+func (p *PooledSet[T]) Insert(data T) int {
+	if idx, ok := p.bucket[data]; ok {
+		return idx // Element already exists
+	}
+
+	box := p.pool.Get().(*T)
+	*box = data
+	p.list = append(p.list, box)
+	p.bucket[data] = len(p.list) - 1
+	return len(p.list) - 1
+}
+
+// Delete removes the specified element from the set using the same swap-delete strategy
+// as Set.Delete, and returns its box to the pool.
+// It returns the index of the deleted element and true, or 0 and false if it does not exist.
+func (p *PooledSet[T]) Delete(element T) (int, bool) {
+	idx, ok := p.bucket[element]
+	if !ok {
+		return 0, false // Element does not exist
+	}
+
+	box := p.list[idx]
+	lastIdx := len(p.list) - 1
+
+	// Move the last box into the freed slot and update its index
+	p.list[idx] = p.list[lastIdx]
+	p.bucket[*p.list[idx]] = idx
+
+	p.list[lastIdx] = nil
+	p.list = p.list[:lastIdx]
+	delete(p.bucket, element)
+
+	p.release(box)
+	return idx, true
+}
+
+// release clears a box so it no longer references the element's data and returns it to the pool.
+func (p *PooledSet[T]) release(box *T) {
+	var zero T
+	*box = zero
+	p.pool.Put(box)
+}
+
+// Exists checks if the specified element is present in the set.
+func (p *PooledSet[T]) Exists(element T) bool {
+	_, ok := p.bucket[element]
+	return ok
+}
+
+// GetRandom returns a copy of a random element from the set. It panics if the set is empty.
+func (p *PooledSet[T]) GetRandom() T {
+	return *p.list[p.rand.Intn(len(p.list))]
+}
+
+// GetRandomOK returns a copy of a random element from the set and true,
+// or the zero value of T and false if the set is empty.
+func (p *PooledSet[T]) GetRandomOK() (T, bool) {
+	if len(p.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return p.GetRandom(), true
+}
+
+// Len returns the number of elements in the set.
+func (p *PooledSet[T]) Len() int {
+	return len(p.list)
+}
+
+// Clear removes all elements from the set, returning every box to the pool.
+func (p *PooledSet[T]) Clear() {
+	for _, box := range p.list {
+		p.release(box)
+	}
+	clear(p.bucket)
+	clear(p.list)
+	p.list = p.list[:0]
+}
+
+// ToSlice returns copies of all elements in the set, in no particular order.
+func (p *PooledSet[T]) ToSlice() []T {
+	out := make([]T, len(p.list))
+	for i, box := range p.list {
+		out[i] = *box
+	}
+	return out
+}
+
+// All returns an iterator over copies of the elements of the set, in no particular order.
+// Modifying the set during iteration is unsupported.
+func (p *PooledSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, box := range p.list {
+			if !yield(*box) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package snapset_test
+
+import (
+	"testing"
+
+	"github.com/snapset"
+)
+
+// record is a large struct, the intended element type of a PooledSet.
+type record struct {
+	id      int
+	payload [24]int64
+}
+
+// TestPooledSet checks value semantics on top of pointer storage.
+func TestPooledSet(t *testing.T) {
+	s := snapset.NewPooled[record](snapset.DefaultBucketSize)
+
+	// Insert elements
+	for i := 0; i < 5; i++ {
+		s.Insert(record{id: i})
+	}
+	if idx := s.Insert(record{id: 2}); idx != 2 || s.Len() != 5 {
+		t.Errorf("Expected existing index 2 and length 5, got %d and %d", idx, s.Len())
+	}
+
+	// Returned values are copies
+	got := s.GetRandom()
+	got.payload[0] = 42
+	if s.Exists(got) {
+		t.Errorf("Modifying a returned value should not affect the set")
+	}
+
+	// Delete and reuse boxes
+	if idx, ok := s.Delete(record{id: 0}); !ok || idx != 0 {
+		t.Errorf("Expected to delete index 0, got %d and %v", idx, ok)
+	}
+	s.Insert(record{id: 5})
+
+	var ids []int
+	for r := range s.All() {
+		ids = append(ids, r.id)
+	}
+	if len(ids) != 5 || s.Exists(record{id: 0}) || !s.Exists(record{id: 5}) {
+		t.Errorf("Expected ids 1 to 5, got %v", ids)
+	}
+
+	s.Clear()
+	if _, ok := s.GetRandomOK(); ok || len(s.ToSlice()) != 0 {
+		t.Errorf("Expected an empty set after Clear")
+	}
+}
@@ -0,0 +1,92 @@
+package snapset
+
+import (
+	"hash/maphash"
+	"math"
+)
+
+// ProbabilisticSet is a Bloom filter: a set that answers membership queries in a fraction
+// of the memory of Set, at the cost of occasional false positives.
+// Exists never reports false for an inserted element, but may report true for an element
+// that was never inserted. Elements themselves are not stored, so ProbabilisticSet cannot
+// return them, delete them, or count them exactly, and it does not satisfy SnapSet.
+// Note: ProbabilisticSet is not safe for concurrent use.
+type ProbabilisticSet[T comparable] struct {
+	bits  []uint64        // bit array of the filter
+	m     uint64          // number of bits in the filter
+	k     int             // number of bits set per element
+	set   uint64          // number of bits currently set
+	seeds [2]maphash.Seed // seeds for the two base hashes
+}
+
+// NewProbabilistic creates and returns a new ProbabilisticSet sized to hold expectedN elements
+// with a false positive rate of about fp. Inserting more elements than expected raises the rate;
+// FalsePositiveRate reports the current estimate.
+// NewProbabilistic panics if expectedN is not positive or fp is not strictly between 0 and 1.
+func NewProbabilistic[T comparable](expectedN int, fp float64) *ProbabilisticSet[T] {
+	if expectedN <= 0 || fp <= 0 || fp >= 1 {
+		panic("snapset: invalid probabilistic set parameters")
+	}
+
+	// Optimal sizes: m = -n ln(fp) / ln(2)^2 bits and k = m/n ln(2) hash functions
+	n := float64(expectedN)
+	m := uint64(math.Ceil(-n * math.Log(fp) / (math.Ln2 * math.Ln2)))
+	k := max(int(math.Round(float64(m)/n*math.Ln2)), 1)
+
+	return &ProbabilisticSet[T]{
+		bits:  make([]uint64, (m+63)/64),
+		m:     m,
+		k:     k,
+		seeds: [2]maphash.Seed{maphash.MakeSeed(), maphash.MakeSeed()},
+	}
+}
+
+// positions calls fn with each of the k bit positions of element,
+// derived from two base hashes by double hashing.
+func (p *ProbabilisticSet[T]) positions(element T, fn func(pos uint64)) {
+	h1 := maphash.Comparable(p.seeds[0], element)
+	h2 := maphash.Comparable(p.seeds[1], element) | 1 // odd, so the positions never collapse to one
+	for i := 0; i < p.k; i++ {
+		fn((h1 + uint64(i)*h2) % p.m)
+	}
+}
+
+// Insert adds the specified element to the set.
+// It returns true if the element was definitely not present before,
+// and false if it was present or is indistinguishable from a present element.
+func (p *ProbabilisticSet[T]) Insert(data T) bool {
+	added := false
+	p.positions(data, func(pos uint64) {
+		word, mask := pos/64, uint64(1)<<(pos%64)
+		if p.bits[word]&mask == 0 {
+			p.bits[word] |= mask
+			p.set++
+			added = true
+		}
+	})
+	return added
+}
+
+// Exists reports whether the specified element may be in the set.
+// A false result is always correct; a true result is wrong with probability FalsePositiveRate.
+func (p *ProbabilisticSet[T]) Exists(element T) bool {
+	found := true
+	p.positions(element, func(pos uint64) {
+		if p.bits[pos/64]&(uint64(1)<<(pos%64)) == 0 {
+			found = false
+		}
+	})
+	return found
+}
+
+// FalsePositiveRate returns the estimated probability that Exists reports true
+// for an element that was never inserted, based on the fraction of bits set so far.
+func (p *ProbabilisticSet[T]) FalsePositiveRate() float64 {
+	return math.Pow(float64(p.set)/float64(p.m), float64(p.k))
+}
+
+// Clear removes all elements from the set, keeping its size.
+func (p *ProbabilisticSet[T]) Clear() {
+	clear(p.bits)
+	p.set = 0
+}
@@ -0,0 +1,49 @@
+package snapset_test
+
+import (
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestProbabilisticSet checks that there are no false negatives and few false positives.
+func TestProbabilisticSet(t *testing.T) {
+	const n = 10000
+	s := snapset.NewProbabilistic[int](n, 0.01)
+
+	if s.FalsePositiveRate() != 0 {
+		t.Errorf("Expected a zero false positive rate for an empty set, got %f", s.FalsePositiveRate())
+	}
+
+	// Insert elements
+	for i := 0; i < n; i++ {
+		s.Insert(i)
+	}
+
+	// No false negatives
+	for i := 0; i < n; i++ {
+		if !s.Exists(i) {
+			t.Fatalf("Expected %d to exist", i)
+		}
+	}
+
+	// False positives stay near the requested rate
+	falsePositives := 0
+	for i := n; i < 2*n; i++ {
+		if s.Exists(i) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / n; rate > 0.03 {
+		t.Errorf("Expected a false positive rate near 0.01, got %f", rate)
+	}
+	if rate := s.FalsePositiveRate(); rate <= 0 || rate > 0.03 {
+		t.Errorf("Expected an estimated false positive rate near 0.01, got %f", rate)
+	}
+
+	// Clear forgets every element
+	s.Clear()
+	if s.Exists(0) {
+		t.Errorf("Expected 0 not to exist after Clear")
+	}
+}
@@ -0,0 +1,71 @@
+package snapset
+
+import "iter"
+
+// ReadOnlySet is a read-only view of a set. It exposes membership checks, random selection,
+// the length and iteration, but no methods that modify the set, so handing a ReadOnlySet
+// to another package guarantees at compile time that it cannot insert or delete elements.
+type ReadOnlySet[T comparable] interface {
+	// Exists checks if the specified element is present in the set.
+	Exists(T) bool
+
+	// GetRandom returns a random element from the set.
+	GetRandom() T
+
+	// GetRandomOK returns a random element from the set and true,
+	// or the zero value and false if the set is empty.
+	GetRandomOK() (T, bool)
+
+	// Len returns the number of elements in the set.
+	Len() int
+
+	// ForEach calls fn for each element of the set until fn returns false.
+	ForEach(fn func(T) bool)
+
+	// All returns an iterator over the elements of the set.
+	All() iter.Seq[T]
+}
+
+// frozenSet is the ReadOnlySet returned by Freeze.
+// It wraps the set so that callers cannot type-assert their way back to the mutating methods.
+type frozenSet[T comparable] struct {
+	set SnapSet[T]
+}
+
+// Exists checks whether the specified element exists in the underlying set.
+func (f frozenSet[T]) Exists(element T) bool {
+	return f.set.Exists(element)
+}
+
+// GetRandom returns a random element from the underlying set.
+func (f frozenSet[T]) GetRandom() T {
+	return f.set.GetRandom()
+}
+
+// GetRandomOK returns a random element from the underlying set and true,
+// or the zero value and false if it is empty.
+func (f frozenSet[T]) GetRandomOK() (T, bool) {
+	return f.set.GetRandomOK()
+}
+
+// Len returns the number of elements in the underlying set.
+func (f frozenSet[T]) Len() int {
+	return f.set.Len()
+}
+
+// ForEach calls fn for each element of the underlying set until fn returns false.
+func (f frozenSet[T]) ForEach(fn func(T) bool) {
+	f.set.ForEach(fn)
+}
+
+// All returns an iterator over the elements of the underlying set.
+func (f frozenSet[T]) All() iter.Seq[T] {
+	return f.set.All()
+}
+
+// Freeze returns a read-only view of the set.
+// The view shares the set's storage instead of copying it, so it is cheap to create,
+// but it reflects any later changes made through the set itself.
+func (s *Set[T]) Freeze() ReadOnlySet[T] {
+	return frozenSet[T]{set: s}
+}
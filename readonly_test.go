@@ -0,0 +1,36 @@
+package snapset_test
+
+import (
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestFreeze checks the read-only view returned by Freeze.
+func TestFreeze(t *testing.T) {
+	s := snapset.NewFromSlice([]string{"alice", "bob"})
+	view := s.Freeze()
+
+	if view.Len() != 2 || !view.Exists("alice") {
+		t.Errorf("View should expose the elements of the set")
+	}
+
+	// The view cannot be converted back into a mutable set
+	if _, ok := view.(snapset.SnapSet[string]); ok {
+		t.Errorf("View should not satisfy SnapSet")
+	}
+
+	count := 0
+	for range view.All() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 elements from iteration, got %d", count)
+	}
+
+	// Changes made through the set are visible in the view
+	s.Insert("carol")
+	if !view.Exists("carol") || view.Len() != 3 {
+		t.Errorf("View should reflect later changes to the set")
+	}
+}
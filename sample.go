@@ -0,0 +1,37 @@
+package snapset
+
+import "math/rand"
+
+// SampleStream consumes ch until it is closed and returns a uniform random sample
+// of at most k of the values received, using reservoir sampling.
+// Only k values are buffered at any time, so it is suitable for unbounded streams.
+// Since the result is a set, values repeated in the stream may collapse,
+// in which case the returned set holds fewer than k elements.
+func SampleStream[T comparable](ch <-chan T, k int) SnapSet[T] {
+	return SampleStreamWithSource(ch, k, newSource())
+}
+
+// SampleStreamWithSource is like SampleStream, but draws its random choices from src,
+// which also seeds the returned set. A fixed-seed source makes the sample reproducible.
+func SampleStreamWithSource[T comparable](ch <-chan T, k int, src rand.Source) SnapSet[T] {
+	k = max(k, 0)
+	s := newSet[T](k, src)
+
+	reservoir := make([]T, 0, k)
+	seen := 0
+	for v := range ch {
+		seen++
+		if len(reservoir) < k {
+			reservoir = append(reservoir, v)
+			continue
+		}
+
+		// Keep the new value with probability k/seen, replacing a random slot
+		if j := s.rand.Intn(seen); j < k {
+			reservoir[j] = v
+		}
+	}
+
+	s.InsertMany(reservoir...)
+	return s
+}
@@ -0,0 +1,64 @@
+package snapset_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// stream sends the values 0..n-1 on a channel and closes it.
+func stream(n int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 0; i < n; i++ {
+			ch <- i
+		}
+	}()
+	return ch
+}
+
+// TestSampleStream checks the size and contents of a reservoir sample.
+func TestSampleStream(t *testing.T) {
+	s := snapset.SampleStream(stream(1000), 10)
+	if s.Len() != 10 {
+		t.Errorf("Expected 10 sampled elements, got %d", s.Len())
+	}
+
+	for _, val := range s.ToSlice() {
+		if val < 0 || val >= 1000 {
+			t.Errorf("Sampled element %d was never sent", val)
+		}
+	}
+
+	// Short streams are returned whole
+	assertElements(t, snapset.SampleStream(stream(3), 10), 0, 1, 2)
+}
+
+// TestSampleStreamUniformity checks that every value is sampled with roughly equal probability.
+func TestSampleStreamUniformity(t *testing.T) {
+	const (
+		n    = 10
+		k    = 3
+		runs = 20000
+	)
+
+	seeds := rand.New(rand.NewSource(42))
+	counts := make([]int, n)
+	for run := 0; run < runs; run++ {
+		s := snapset.SampleStreamWithSource(stream(n), k, rand.NewSource(seeds.Int63()))
+		for _, val := range s.ToSlice() {
+			counts[val]++
+		}
+	}
+
+	// Each value should appear in about k/n of the samples
+	expected := float64(runs) * k / n
+	for val, count := range counts {
+		if math.Abs(float64(count)-expected)/expected > 0.05 {
+			t.Errorf("Value %d sampled %d times, expected about %.0f", val, count, expected)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package snapset
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+)
+
+// NewSecure creates and returns a new instance of Set with the specified initial size
+// whose random selections draw from crypto/rand instead of a seeded PRNG.
+// Use it when the chosen element must not be predictable, e.g. for leader election.
+// Every draw reads from the operating system's CSPRNG, which is considerably slower
+// than the default generator. Sets derived from it by Clone, CloneCOW, Filter and Partition
+// draw from crypto/rand too; only an explicit SetRand replaces the secure source.
+func NewSecure[T comparable](size int) SnapSet[T] {
+	s := newSet[T](size, cryptoSource{})
+	s.newRand = newCryptoRand
+	return s
+}
+
+// newCryptoRand returns a generator backed by crypto/rand.
+// Sets derived from a secure set, such as clones and filtered sets, use it as well.
+func newCryptoRand() *rand.Rand {
+	return rand.New(cryptoSource{})
+}
+
+// cryptoSource is a rand.Source64 backed by crypto/rand.
+// It keeps no state, so Seed is a no-op.
+type cryptoSource struct{}
+
+// Int63 returns a non-negative random 63-bit integer.
+func (cryptoSource) Int63() int64 {
+	return int64(cryptoSource{}.Uint64() &^ (1 << 63))
+}
+
+// Uint64 returns a random 64-bit integer read from crypto/rand.
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		panic("snapset: crypto/rand read failed: " + err.Error())
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// Seed is a no-op; the secure source cannot be seeded.
+func (cryptoSource) Seed(int64) {}
+
+var _ rand.Source64 = cryptoSource{}
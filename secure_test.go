@@ -0,0 +1,70 @@
+package snapset_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestNewSecure checks that a secure set behaves like a regular set and selects every element.
+func TestNewSecure(t *testing.T) {
+	s := snapset.NewSecure[int](snapset.DefaultBucketSize)
+
+	// Empty set has nothing to pick
+	if _, ok := s.GetRandomOK(); ok {
+		t.Errorf("Expected GetRandomOK to fail on an empty set")
+	}
+
+	// Insert elements
+	s.InsertMany(1, 2, 3)
+
+	// Every element should eventually be picked
+	seen := make(map[int]bool)
+	for i := 0; i < 1000 && len(seen) < 3; i++ {
+		seen[s.GetRandom()] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected all 3 elements to be picked, got %v", seen)
+	}
+}
+
+// TestNewSecureDerived checks that sets derived from a secure set still draw from crypto/rand.
+func TestNewSecureDerived(t *testing.T) {
+	s := snapset.NewSecure[int](snapset.DefaultBucketSize)
+	for i := 0; i < 1000; i++ {
+		s.Insert(i)
+	}
+
+	// A seedable generator repeats itself after reseeding; crypto/rand does not
+	predictable := func(d snapset.SnapSet[int]) bool {
+		snapset.SeedRand(d, 1)
+		first := d.GetRandomN(20)
+		snapset.SeedRand(d, 1)
+		return slices.Equal(first, d.GetRandomN(20))
+	}
+
+	if !predictable(snapset.NewFromSlice(s.ToSlice()).Clone()) {
+		t.Fatalf("Expected a clone of a regular set to be predictable after reseeding")
+	}
+
+	matched, unmatched := s.Partition(func(val int) bool { return val%2 == 0 })
+	derived := map[string]snapset.SnapSet[int]{
+		"Clone":               s.Clone(),
+		"CloneCOW":            s.CloneCOW(),
+		"Filter":              s.Filter(func(val int) bool { return val > 10 }),
+		"Partition":           matched,
+		"Partition unmatched": unmatched,
+	}
+	for name, d := range derived {
+		if predictable(d) {
+			t.Errorf("Expected %s of a secure set to draw from crypto/rand", name)
+		}
+	}
+
+	// SetRand(nil) keeps the secure kind as well
+	s.SetRand(nil)
+	if predictable(s) {
+		t.Errorf("Expected SetRand(nil) on a secure set to keep crypto/rand")
+	}
+}
@@ -1,7 +1,15 @@
 package snapset
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"maps"
 	"math/rand"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -18,42 +26,456 @@ type SnapSet[T comparable] interface {
 	Exists(T) bool
 
 	// GetRandom returns a random element from the set.
+	// The set must not be empty: callers must check Len first, or use GetRandomOK
+	// or MustGetRandom. Calling it on an empty set panics with an unspecified message.
 	GetRandom() T
+
+	// GetRandomOK returns a random element from the set and true,
+	// or the zero value and false if the set is empty.
+	GetRandomOK() (T, bool)
+
+	// Len returns the number of elements in the set.
+	Len() int
+
+	// Clear removes all elements from the set.
+	Clear()
+
+	// ToSlice returns a copy of all elements in the set.
+	ToSlice() []T
+
+	// Clone returns an independent copy of the set.
+	Clone() SnapSet[T]
+
+	// IsSubset reports whether every element of the set is also in other.
+	IsSubset(other SnapSet[T]) bool
+
+	// IsSuperset reports whether every element of other is also in the set.
+	IsSuperset(other SnapSet[T]) bool
+
+	// Equal reports whether the set and other contain exactly the same elements.
+	Equal(other SnapSet[T]) bool
+
+	// Pop removes and returns a random element from the set.
+	Pop() (T, bool)
+
+	// InsertMany adds all given elements to the set and returns how many were newly added.
+	InsertMany(data ...T) int
+
+	// DeleteMany removes all given elements from the set and returns how many were removed.
+	DeleteMany(data ...T) int
+
+	// ForEach calls fn for each element of the set until fn returns false.
+	ForEach(fn func(T) bool)
+
+	// All returns an iterator over the elements of the set.
+	All() iter.Seq[T]
+
+	// Snapshot captures the current contents of the set.
+	Snapshot() Snapshot[T]
+
+	// Restore resets the set to the contents captured by snap.
+	Restore(snap Snapshot[T])
+
+	// Checkpoint saves the current contents of the set under name.
+	Checkpoint(name string)
+
+	// Rollback restores the set to the checkpoint saved under name.
+	Rollback(name string) bool
+
+	// GetRandomN returns up to n distinct random elements from the set.
+	GetRandomN(n int) []T
+
+	// Cap returns the capacity of the set's backing slice.
+	Cap() int
+
+	// Compact shrinks the backing storage to fit the current elements.
+	Compact()
+
+	// Grow ensures the set can hold n more elements without reallocating.
+	Grow(n int)
+
+	// Filter returns a new set containing the elements for which pred returns true.
+	Filter(pred func(T) bool) SnapSet[T]
+
+	// RemoveIf deletes every element for which pred returns true and returns how many were removed.
+	RemoveIf(pred func(T) bool) int
+
+	// IndexOf returns the current index of the specified element.
+	IndexOf(element T) (int, bool)
+
+	// At returns the element stored at the specified index.
+	At(idx int) (T, bool)
+
+	// ContainsAll reports whether every given element exists in the set.
+	ContainsAll(elems ...T) bool
+
+	// ContainsAny reports whether at least one given element exists in the set.
+	ContainsAny(elems ...T) bool
+
+	// Merge inserts every element of other into the set and returns how many were newly added.
+	Merge(other SnapSet[T]) int
+
+	// RetainAll removes every element not present in other and returns how many were removed.
+	RetainAll(other SnapSet[T]) int
+
+	// AllIndexed returns an iterator over the index and element pairs of the set.
+	AllIndexed() iter.Seq2[int, T]
+
+	// PopN removes and returns up to n random elements from the set.
+	PopN(n int) []T
+
+	// Freeze returns a read-only view of the set.
+	Freeze() ReadOnlySet[T]
+
+	// CopyInto copies as many elements as fit into dst and returns the number copied.
+	CopyInto(dst []T) int
+
+	// IsDisjoint reports whether the set and other share no elements.
+	IsDisjoint(other SnapSet[T]) bool
+
+	// GetRandomExcept returns a random element that is not in exclude.
+	GetRandomExcept(exclude SnapSet[T]) (T, bool)
+
+	// GetRandomFrom returns a random element chosen with the provided generator.
+	GetRandomFrom(r *rand.Rand) (T, bool)
+
+	// GetRandomWithReplacement returns n random elements, allowing repeats.
+	GetRandomWithReplacement(n int) []T
+
+	// Stats returns the current size of the set and its cumulative activity counters.
+	Stats() SetStats
+
+	// InsertIfAbsent adds an element and reports whether it was newly added.
+	InsertIfAbsent(data T) (int, bool)
+
+	// Replace swaps oldElement for newElement at the same index.
+	Replace(oldElement, newElement T) bool
+
+	// Count returns how many elements satisfy pred.
+	Count(pred func(T) bool) int
+
+	// Any reports whether at least one element satisfies pred.
+	Any(pred func(T) bool) bool
+
+	// Every reports whether all elements satisfy pred.
+	Every(pred func(T) bool) bool
+
+	// SetRand replaces the generator used for random selection.
+	SetRand(r *rand.Rand)
+
+	// InsertFromChan inserts every value received from ch until it is closed.
+	InsertFromChan(ch <-chan T) int
+
+	// Stream sends the current elements over the returned channel until ctx is cancelled.
+	Stream(ctx context.Context) <-chan T
+
+	// Partition splits the set into the elements that satisfy pred and those that do not.
+	Partition(pred func(T) bool) (matched, unmatched SnapSet[T])
+
+	// RemoveAll removes every element that is present in other.
+	RemoveAll(other SnapSet[T]) int
+
+	// MustGetRandom returns a random element, panicking with a descriptive message if the set is empty.
+	MustGetRandom() T
+
+	// Missing returns the given elements that are not in the set, in argument order.
+	Missing(elems ...T) []T
+
+	// IntersectionCount returns the number of elements the set shares with other.
+	IntersectionCount(other SnapSet[T]) int
+
+	// GetRandomIndexed returns a random element together with its index in the internal list.
+	GetRandomIndexed() (T, int, bool)
+
+	// InsertTracked adds an element and reports whether the backing slice had to grow.
+	InsertTracked(data T) (idx int, grew bool)
+
+	// Shuffle randomly permutes the internal order of the elements.
+	Shuffle()
+
+	// DrainRandom returns an iterator that removes and yields elements in random order until the set is empty.
+	DrainRandom() iter.Seq[T]
+
+	// CloneCOW returns a clone that shares storage with the set until either is modified.
+	CloneCOW() SnapSet[T]
+
+	// ExistsAll reports, for each given element, whether it is present in the set.
+	ExistsAll(elems []T) []bool
+
+	// LastInserted returns the most recently inserted element, if it is still in the set.
+	LastInserted() (T, bool)
+
+	// DeleteTracked removes an element and reports which element, if any, was moved into its slot.
+	DeleteTracked(element T) (deletedIdx int, movedElem T, moved bool, ok bool)
+
+	// IterateSafe calls fn for a snapshot of the elements and removes those for which fn returns false.
+	IterateSafe(fn func(T) (keep bool))
+
+	// Zero returns the zero value of the element type.
+	Zero() T
+
+	// ContentHash returns a hash of the elements that does not depend on their order.
+	ContentHash() uint64
 }
 
 // DefaultBucketSize is the default initial size of the internal bucket map.
 const DefaultBucketSize = 1 << 5
 
+// maxExceptTries is the number of random picks GetRandomExcept attempts before falling back to a scan.
+const maxExceptTries = 8
+
+// shrinkMinHint is the bucket map size below which the map is never shrunk automatically.
+const shrinkMinHint = 1 << 10
+
+// shrinkFactor controls automatic shrinking: the bucket map is rebuilt once the number of
+// elements falls below 1/shrinkFactor of the number of entries the map has been sized for.
+const shrinkFactor = 10
+
+// maxStringElements is the maximum number of elements included in the output of String.
+const maxStringElements = 20
+
+// contentSeed seeds the per-element hashes combined by ContentHash.
+// It is chosen once per process, so content hashes are only comparable within a process.
+var contentSeed = maphash.MakeSeed()
+
 // Set is a generic set implementation that uses a map and a slice to store elements.
 // The map (bucket) maps elements to their indices in the slice (list).
 // The slice stores the elements and allows for efficient random access.
 type Set[T comparable] struct {
-	bucket  map[T]int  // maps elements to their indices in the list
-	list    []T        // stores the elements
-	currIdx int        // current index (index of the last inserted element)
-	rand    *rand.Rand // random number generator for GetRandom
+	bucket  map[T]int         // maps elements to their indices in the list
+	list    []T               // stores the elements
+	currIdx int               // index of the most recently inserted element, or -1 if it has since been removed
+	rand    *rand.Rand        // random number generator for GetRandom
+	newRand func() *rand.Rand // creates generators for sets derived from this one; nil means time-seeded
+
+	bucketHint  int                    // number of entries the bucket map has been sized for
+	checkpoints map[string]Snapshot[T] // named restore points saved by Checkpoint
+	hooks       Hooks[T]               // callbacks invoked after insertions and removals
+
+	maxSize int        // maximum number of elements, or 0 when the set is unbounded
+	onEvict func(T)    // called with each element evicted to respect maxSize
+	victim  func() int // picks the index of the element to evict; nil means a random one
+
+	cow    *cowRefs                     // shared storage references left by CloneCOW, or nil when the storage is private
+	growth func(oldCap, needed int) int // capacity policy for the list; nil means append's default
+
+	inserts       uint64 // number of elements added since creation
+	deletes       uint64 // number of elements removed since creation
+	failedDeletes uint64 // number of Delete calls for absent elements since creation
+	reallocs      uint64 // number of times inserting reallocated the list
 }
 
 // New creates and returns a new instance of Set with the specified initial size.
-// It initializes the internal bucket map and random number generator.
+// It initializes the internal bucket map and list, both presized to hold size elements,
+// and a random number generator seeded from the current time.
+// A negative size is treated as zero; use NewChecked to reject it instead.
 func New[T comparable](size int) SnapSet[T] {
+	return NewWithSource[T](size, newSource())
+}
+
+// ErrNegativeSize is returned by NewChecked when the requested size is negative.
+var ErrNegativeSize = errors.New("snapset: negative size")
+
+// NewChecked creates and returns a new instance of Set with the specified initial size,
+// like New, but returns ErrNegativeSize instead of clamping when size is negative.
+// It suits sizes computed from user input, where a negative value indicates a bug upstream.
+func NewChecked[T comparable](size int) (SnapSet[T], error) {
+	if size < 0 {
+		return nil, ErrNegativeSize
+	}
+	return New[T](size), nil
+}
+
+// NewWithSource creates and returns a new instance of Set with the specified initial size
+// whose random number generator draws from src.
+// Passing a fixed-seed source such as rand.NewSource(42) makes GetRandom reproducible.
+// Every method that makes a random choice draws from this one generator, including GetRandomN,
+// Pop, PopN, Shuffle and bounded-set eviction, so two sets built from equal seeds and given
+// the same sequence of operations produce identical results. Only GetRandomFrom uses
+// the generator passed to it instead.
+func NewWithSource[T comparable](size int, src rand.Source) SnapSet[T] {
+	return newSet[T](size, src)
+}
+
+// NewFromSlice creates and returns a new instance of Set containing the elements of items.
+// The bucket map is pre-sized to len(items), and duplicate items are collapsed to a single element.
+func NewFromSlice[T comparable](items []T) SnapSet[T] {
+	s := newSet[T](len(items), newSource())
+	s.InsertMany(items...)
+	return s
+}
+
+// NewWithGrowth creates and returns a new instance of Set with the specified initial size
+// whose backing slice grows according to growth instead of append's default doubling.
+// Whenever the slice is full, growth is called with its current capacity and the number of
+// elements it must now hold, and returns the new capacity; results below needed are raised to it.
+// For example, growing by fixed steps of 1024 caps the memory overshoot of large sets.
+// A nil growth falls back to the default. Explicit calls to Grow are not affected.
+func NewWithGrowth[T comparable](size int, growth func(oldCap, needed int) int) SnapSet[T] {
+	s := newSet[T](size, newSource())
+	s.growth = growth
+	return s
+}
+
+// newSet creates a new Set with the specified initial size and random source.
+// It backs the exported constructors and the variants that wrap a Set.
+// A negative size is treated as zero, so sizes computed from untrusted input are safe.
+func newSet[T comparable](size int, src rand.Source) *Set[T] {
+	return newSetRand[T](size, rand.New(src))
+}
+
+// newSetRand is like newSet but uses the given generator as is,
+// for callers that already hold one and must not pay for seeding a fresh source.
+func newSetRand[T comparable](size int, r *rand.Rand) *Set[T] {
+	size = max(size, 0)
 	return &Set[T]{
-		bucket: make(map[T]int, size),
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		bucket:     make(map[T]int, size),
+		list:       make([]T, 0, size),
+		currIdx:    -1,
+		rand:       r,
+		bucketHint: size,
+	}
+}
+
+// spawnRand returns a new generator of the same kind as the set's own,
+// so that sets derived from a NewSecure set keep drawing from crypto/rand.
+func (s *Set[T]) spawnRand() *rand.Rand {
+	if s.newRand != nil {
+		return s.newRand()
 	}
+	return rand.New(newSource())
+}
+
+// derive creates an empty set with the specified initial size whose generator
+// is of the same kind as the set's own. It backs the methods that return new sets.
+func (s *Set[T]) derive(size int) *Set[T] {
+	d := newSetRand[T](size, s.spawnRand())
+	d.newRand = s.newRand
+	return d
+}
+
+// newSource returns a random source seeded from the current time.
+func newSource() rand.Source {
+	return rand.NewSource(time.Now().UnixNano())
 }
 
 // Insert adds the specified element to the set.
-// It appends the element to the list, updates the bucket map with the new index,
+// If the element already exists, the set is left unchanged and its existing index is returned.
+// Otherwise it appends the element to the list, updates the bucket map with the new index,
 // and updates the current index.
 // It returns the index of the inserted element.
 func (s *Set[T]) Insert(data T) int {
+	if idx, ok := s.bucket[data]; ok {
+		return idx // Element already exists
+	}
+
+	s.own()
+
+	// Make room in a bounded set before adding
+	if s.full() {
+		s.evict()
+	}
+
+	if len(s.list) == cap(s.list) {
+		s.growList(len(s.list) + 1)
+	}
 	s.list = append(s.list, data)
 	s.currIdx = len(s.list) - 1
 	s.bucket[data] = s.currIdx
+	s.bucketHint = max(s.bucketHint, len(s.list))
+	s.inserts++
+
+	s.inserted(data)
 	return s.currIdx
 }
 
+// LastInserted returns the element most recently added to the set, and true.
+// It returns the zero value of T and false if nothing has been inserted, or if that element
+// has since been removed; it does not fall back to an earlier insertion.
+// Re-inserting an existing element is a no-op and does not change the result,
+// while Replace counts as inserting the new element. Deleting other elements,
+// even when the swap-delete moves the last inserted element, leaves the result unchanged.
+func (s *Set[T]) LastInserted() (T, bool) {
+	if s.currIdx < 0 {
+		var zero T
+		return zero, false
+	}
+	return s.list[s.currIdx], true
+}
+
+// growList moves the list to a larger backing array that holds at least needed elements.
+// The new capacity comes from the growth policy if one is set, and from append's
+// default doubling otherwise. A bounded set never holds more than maxSize elements,
+// so its capacity is clamped to the bound and it is not grown at all once it reaches it.
+func (s *Set[T]) growList(needed int) {
+	if s.growth == nil && s.maxSize == 0 {
+		s.list = slices.Grow(s.list, needed-len(s.list))
+		s.reallocs++
+		return
+	}
+
+	newCap := 2 * cap(s.list)
+	if s.growth != nil {
+		newCap = s.growth(cap(s.list), needed)
+	}
+	newCap = max(newCap, needed)
+	if s.maxSize > 0 {
+		newCap = min(newCap, s.maxSize)
+		if newCap <= cap(s.list) {
+			return
+		}
+	}
+
+	list := make([]T, len(s.list), newCap)
+	copy(list, s.list)
+	s.list = list
+	s.reallocs++
+}
+
+// InsertTracked adds the specified element to the set like Insert, and also reports
+// whether doing so reallocated the backing slice. Measuring how often this happens
+// helps to choose the initial size; the running total is reported by Stats as Reallocs.
+func (s *Set[T]) InsertTracked(data T) (idx int, grew bool) {
+	before := s.reallocs
+	idx = s.Insert(data)
+	return idx, s.reallocs != before
+}
+
+// InsertIfAbsent adds the specified element to the set if it does not already exist.
+// It returns the element's current index either way, along with true if the element
+// was newly added or false if it was already present. Under ConcurrentSet the check
+// and the insertion happen under one lock, avoiding a check-then-act race.
+func (s *Set[T]) InsertIfAbsent(data T) (int, bool) {
+	if idx, ok := s.bucket[data]; ok {
+		return idx, false // Element already exists
+	}
+	return s.Insert(data), true
+}
+
+// Replace swaps oldElement for newElement at the same index of the list and updates the bucket map.
+// Unlike a Delete followed by an Insert, no other element moves, so external references
+// to indices stay valid. Hooks and counters treat it as a removal of oldElement
+// followed by an insertion of newElement.
+// It returns false, leaving the set unchanged, if oldElement does not exist or newElement already exists.
+func (s *Set[T]) Replace(oldElement, newElement T) bool {
+	idx, ok := s.bucket[oldElement]
+	if !ok || s.Exists(newElement) {
+		return false
+	}
+
+	s.own()
+	s.list[idx] = newElement
+	delete(s.bucket, oldElement)
+	s.bucket[newElement] = idx
+	s.currIdx = idx
+	s.deletes++
+	s.inserts++
+
+	s.deleted(oldElement)
+	s.inserted(newElement)
+	return true
+}
+
 // Delete removes the specified element from the set.
 // If the element exists, it swaps the element with the last element in the list,
 // updates the bucket map accordingly, removes the last element from the list,
@@ -63,9 +485,40 @@ func (s *Set[T]) Insert(data T) int {
 func (s *Set[T]) Delete(element T) (int, bool) {
 	idx, ok := s.bucket[element]
 	if !ok {
+		s.failedDeletes++
 		return 0, false // Element does not exist
 	}
 
+	s.removeAt(idx)
+	return idx, true
+}
+
+// DeleteTracked removes the specified element from the set like Delete, and also reports
+// the effect of the swap-delete on indices: movedElem is the element that was moved from
+// the end of the list into the freed slot deletedIdx, and moved is false if no element moved
+// because the deleted one was last. If the element does not exist, ok is false.
+// Callers keeping parallel arrays keyed by index can apply the same move to stay aligned.
+func (s *Set[T]) DeleteTracked(element T) (deletedIdx int, movedElem T, moved bool, ok bool) {
+	idx, ok := s.bucket[element]
+	if !ok {
+		s.failedDeletes++
+		return 0, movedElem, false, false // Element does not exist
+	}
+
+	lastIdx := len(s.list) - 1
+	if idx != lastIdx {
+		movedElem, moved = s.list[lastIdx], true
+	}
+
+	s.removeAt(idx)
+	return idx, movedElem, moved, true
+}
+
+// removeAt removes the element stored at idx using the swap-delete strategy
+// and returns the removed element. The caller must ensure idx is within bounds.
+func (s *Set[T]) removeAt(idx int) T {
+	s.own()
+	element := s.list[idx]
 	lastIdx := len(s.list) - 1
 
 	// Swap the element with the last element in the list
@@ -81,10 +534,46 @@ func (s *Set[T]) Delete(element T) (int, bool) {
 	// Delete the element from the bucket map
 	delete(s.bucket, element)
 
-	// Update the current index
-	s.currIdx = len(s.list) - 1
+	// Update the current index: the last inserted element is either gone or may have moved to idx
+	if s.currIdx == idx {
+		s.currIdx = -1
+	} else if s.currIdx == lastIdx {
+		s.currIdx = idx
+	}
+	s.deletes++
 
-	return idx, true
+	s.maybeShrink()
+	s.deleted(element)
+	return element
+}
+
+// swapped keeps currIdx pointing at the most recently inserted element
+// after the elements at i and j have traded places.
+func (s *Set[T]) swapped(i, j int) {
+	switch s.currIdx {
+	case i:
+		s.currIdx = j
+	case j:
+		s.currIdx = i
+	}
+}
+
+// maybeShrink rebuilds the bucket map once the set has shrunk far below the size the map
+// was grown to. Go maps never release their buckets, so without this a set that grew large
+// and then shrank would keep the memory of its peak size. The rebuild costs time linear in
+// the remaining elements, which is amortized over the many deletions needed to trigger it.
+func (s *Set[T]) maybeShrink() {
+	if s.bucketHint >= shrinkMinHint && len(s.list) < s.bucketHint/shrinkFactor {
+		s.rebuildBucket(len(s.list))
+	}
+}
+
+// rebuildBucket replaces the bucket map with a freshly allocated one sized for size entries.
+func (s *Set[T]) rebuildBucket(size int) {
+	bucket := make(map[T]int, size)
+	maps.Copy(bucket, s.bucket)
+	s.bucket = bucket
+	s.bucketHint = size
 }
 
 // Exists checks whether the specified element exists in the set.
@@ -94,11 +583,786 @@ func (s *Set[T]) Exists(element T) bool {
 	return ok
 }
 
+// ContainsAll reports whether every given element exists in the set.
+// It returns false as soon as a missing element is found, and true when called without arguments.
+func (s *Set[T]) ContainsAll(elems ...T) bool {
+	for _, element := range elems {
+		if !s.Exists(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny reports whether at least one given element exists in the set.
+// It returns true as soon as an existing element is found, and false when called without arguments.
+func (s *Set[T]) ContainsAny(elems ...T) bool {
+	for _, element := range elems {
+		if s.Exists(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExistsAll returns a slice of the same length as elems whose i-th entry reports
+// whether elems[i] is present in the set.
+func (s *Set[T]) ExistsAll(elems []T) []bool {
+	out := make([]bool, len(elems))
+	for i, element := range elems {
+		_, out[i] = s.bucket[element]
+	}
+	return out
+}
+
+// Missing returns the elements among elems that do not exist in the set, preserving their order.
+// It returns nil when every element is present, which makes it suited to reporting
+// exactly which required elements are absent. Repeated arguments are reported as often as they appear.
+func (s *Set[T]) Missing(elems ...T) []T {
+	var missing []T
+	for _, element := range elems {
+		if !s.Exists(element) {
+			missing = append(missing, element)
+		}
+	}
+	return missing
+}
+
+// IndexOf returns the index of the specified element in the internal list and true,
+// or 0 and false if the element does not exist.
+// Indices are not stable: Delete moves the last element into the freed slot,
+// so an index is only valid until the next deletion.
+func (s *Set[T]) IndexOf(element T) (int, bool) {
+	idx, ok := s.bucket[element]
+	return idx, ok
+}
+
+// At returns the element stored at the specified index of the internal list and true,
+// or the zero value of T and false if idx is out of range.
+// Together with Len, it lets callers walk or sample the list themselves.
+// Indices are only valid until the next Delete, which may move elements.
+func (s *Set[T]) At(idx int) (T, bool) {
+	if idx < 0 || idx >= len(s.list) {
+		var zero T
+		return zero, false
+	}
+	return s.list[idx], true
+}
+
 // GetRandom returns a random element from the set.
 // It generates a random index within the range of the list and returns the element at that index.
+// It panics if the set is empty; see GetRandomOK and MustGetRandom for guarded variants.
 // Note: This method is not safe for concurrent use.
 func (s *Set[T]) GetRandom() T {
 	// Generate a random index using the random number generator
 	rIdx := s.rand.Intn(len(s.list))
 	return s.list[rIdx]
 }
+
+// MustGetRandom returns a random element from the set.
+// Unlike GetRandom, whose empty-set panic comes from deep inside math/rand,
+// it panics with "snapset: GetRandom on empty set" so the cause is obvious.
+func (s *Set[T]) MustGetRandom() T {
+	if len(s.list) == 0 {
+		panic("snapset: GetRandom on empty set")
+	}
+	return s.GetRandom()
+}
+
+// GetRandomOK returns a random element from the set and true.
+// Unlike GetRandom, it does not panic on an empty set; it returns the zero value of T and false instead.
+func (s *Set[T]) GetRandomOK() (T, bool) {
+	if len(s.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.GetRandom(), true
+}
+
+// GetRandomIndexed returns a random element from the set, its index in the internal list, and true,
+// or the zero value of T, 0 and false if the set is empty.
+// The index can be passed to At or compared with IndexOf; like any index,
+// it is only valid until the next deletion moves elements around.
+func (s *Set[T]) GetRandomIndexed() (T, int, bool) {
+	if len(s.list) == 0 {
+		var zero T
+		return zero, 0, false
+	}
+	idx := s.rand.Intn(len(s.list))
+	return s.list[idx], idx, true
+}
+
+// GetRandomFrom returns a random element from the set chosen with r, and true,
+// or the zero value of T and false if the set is empty.
+// The set's own generator is not touched, so callers can use goroutine-local generators.
+func (s *Set[T]) GetRandomFrom(r *rand.Rand) (T, bool) {
+	if len(s.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.list[r.Intn(len(s.list))], true
+}
+
+// SetRand replaces the generator used by GetRandom and the other random selection methods.
+// Passing nil installs a fresh generator of the set's kind: a crypto/rand-backed one
+// for a set created by NewSecure, and one seeded from the current time otherwise.
+// Like every other Set method it is not safe for concurrent use; use ConcurrentSet for that.
+func (s *Set[T]) SetRand(r *rand.Rand) {
+	if r == nil {
+		r = s.spawnRand()
+	}
+	s.rand = r
+}
+
+// Zero returns the zero value of T. It does not depend on the contents of the set,
+// and exists for generic code that holds a SnapSet[T] but cannot name T.
+func (s *Set[T]) Zero() T {
+	var zero T
+	return zero
+}
+
+// ContentHash returns a hash of the set's contents that does not depend on the order of the elements.
+// It sums the maphash of every element, so equal sets hash equally no matter how they were built,
+// and different sets collide only with the usual probability of a 64-bit hash.
+// Hashes are seeded per process: they can serve as in-memory cache keys but must not be persisted.
+func (s *Set[T]) ContentHash() uint64 {
+	var sum uint64
+	for _, element := range s.list {
+		sum += maphash.Comparable(contentSeed, element)
+	}
+	return sum
+}
+
+// Len returns the number of elements currently stored in the set.
+// It runs in constant time and reflects all preceding inserts and deletes.
+func (s *Set[T]) Len() int {
+	return len(s.list)
+}
+
+// Clear removes all elements from the set, leaving it empty and ready for reuse.
+// The backing slice is truncated to zero length but keeps its capacity, and the bucket map
+// keeps its allocated buckets, so memory is not released and subsequent inserts avoid re-growing.
+func (s *Set[T]) Clear() {
+	var removed []T
+	if s.hooks.OnDelete != nil {
+		removed = slices.Clone(s.list)
+	}
+	s.deletes += uint64(len(s.list))
+
+	s.own()
+	clear(s.bucket)
+	clear(s.list) // Drop references held by the truncated elements
+	s.list = s.list[:0]
+	s.currIdx = -1
+
+	for _, element := range removed {
+		s.deleted(element)
+	}
+}
+
+// ToSlice returns a newly allocated slice containing every element of the set.
+// The returned slice is a copy, so modifying it does not affect the set.
+// The order of elements is unspecified, since Delete reorders the internal list.
+// Use an OrderedSet for an order derived from the insertion sequence, or Sorted for a canonical one.
+func (s *Set[T]) ToSlice() []T {
+	out := make([]T, len(s.list))
+	copy(out, s.list)
+	return out
+}
+
+// CopyInto copies as many elements of the set as fit into dst and returns the number copied,
+// which is the minimum of Len() and len(dst). It lets callers reuse a buffer instead of
+// allocating a new slice with ToSlice. The order of the copied elements is arbitrary.
+func (s *Set[T]) CopyInto(dst []T) int {
+	return copy(dst, s.list)
+}
+
+// Clone returns a deep copy of the set.
+// The clone gets its own bucket map, list slice and random number generator,
+// so mutations on either set are never visible through the other.
+// Hooks and checkpoints are not carried over to the clone.
+// A size bound, a growth policy and the kind of random source are kept, but the eviction callback is not.
+func (s *Set[T]) Clone() SnapSet[T] {
+	c := &Set[T]{
+		bucket:     make(map[T]int, len(s.bucket)),
+		list:       make([]T, len(s.list)),
+		currIdx:    s.currIdx,
+		rand:       s.spawnRand(),
+		newRand:    s.newRand,
+		bucketHint: len(s.bucket),
+		maxSize:    s.maxSize,
+		growth:     s.growth,
+	}
+	copy(c.list, s.list)
+	for element, idx := range s.bucket {
+		c.bucket[element] = idx
+	}
+	return c
+}
+
+// IsSubset reports whether every element of the set is also present in other.
+// It returns false as soon as an element is found missing from other.
+// The empty set is a subset of every set.
+func (s *Set[T]) IsSubset(other SnapSet[T]) bool {
+	if len(s.list) > other.Len() {
+		return false // A larger set cannot fit inside a smaller one
+	}
+
+	for _, element := range s.list {
+		if !other.Exists(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether every element of other is also present in the set.
+// It returns false as soon as an element of other is found missing from the set.
+func (s *Set[T]) IsSuperset(other SnapSet[T]) bool {
+	if other.Len() > len(s.list) {
+		return false // A smaller set cannot contain a larger one
+	}
+
+	for _, element := range other.ToSlice() {
+		if !s.Exists(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether the set and other contain exactly the same elements.
+// The comparison ignores the internal order of elements. Sets of different lengths
+// are rejected immediately; otherwise every element of the set is checked against other.
+func (s *Set[T]) Equal(other SnapSet[T]) bool {
+	if len(s.list) != other.Len() {
+		return false
+	}
+
+	for _, element := range s.list {
+		if !other.Exists(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// Pop removes a random element from the set and returns it with true.
+// The element is picked by index and removed with the same swap-delete strategy as Delete,
+// so no additional map lookup is needed to locate it.
+// If the set is empty, it returns the zero value of T and false.
+func (s *Set[T]) Pop() (T, bool) {
+	if len(s.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.removeAt(s.rand.Intn(len(s.list))), true
+}
+
+// InsertMany adds all given elements to the set, skipping those that already exist.
+// The backing slice is grown once up front to fit len(data) more elements,
+// rather than growing incrementally with each insertion.
+// It returns the number of elements that were newly added.
+func (s *Set[T]) InsertMany(data ...T) int {
+	if needed := len(s.list) + len(data); needed > cap(s.list) {
+		s.growList(needed)
+	}
+
+	added := 0
+	for _, element := range data {
+		if s.Exists(element) {
+			continue // Element already exists
+		}
+		s.Insert(element)
+		added++
+	}
+	return added
+}
+
+// InsertFromChan inserts every value received from ch until ch is closed.
+// It blocks until then and returns the number of elements newly added.
+func (s *Set[T]) InsertFromChan(ch <-chan T) int {
+	added := 0
+	for element := range ch {
+		if s.Exists(element) {
+			continue // Element already exists
+		}
+		s.Insert(element)
+		added++
+	}
+	return added
+}
+
+// DeleteMany removes all given elements from the set using the same swap-delete strategy as Delete.
+// Elements that do not exist, including repeated occurrences already removed earlier in the batch,
+// are skipped.
+// It returns the number of elements actually removed.
+func (s *Set[T]) DeleteMany(data ...T) int {
+	removed := 0
+	for _, element := range data {
+		if _, ok := s.Delete(element); ok {
+			removed++
+		}
+	}
+	return removed
+}
+
+// Stream returns a channel over which the set's elements are sent from a separate goroutine.
+// The elements are snapshotted at call time, so later mutations are not reflected.
+// The channel is closed after the last element, or as soon as ctx is cancelled;
+// callers that stop reading early must cancel ctx to release the goroutine.
+func (s *Set[T]) Stream(ctx context.Context) <-chan T {
+	return stream(ctx, s.ToSlice())
+}
+
+// stream sends elements over a new channel until they run out or ctx is cancelled.
+func stream[T any](ctx context.Context, elements []T) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, element := range elements {
+			select {
+			case ch <- element:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// ForEach calls fn for each element of the set, in no particular order.
+// Iteration stops early as soon as fn returns false. Unlike ToSlice, it does not allocate.
+// Mutating the set from within fn is undefined behavior, since Delete moves elements around the list.
+func (s *Set[T]) ForEach(fn func(T) bool) {
+	for _, element := range s.list {
+		if !fn(element) {
+			return
+		}
+	}
+}
+
+// All returns an iterator that yields every element of the set exactly once, in no particular order.
+// It is intended for use with range-over-func loops and the iterator helpers of the slices and maps packages.
+// Modifying the set during iteration is unsupported.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.list {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// String implements fmt.Stringer, formatting the set as snapset{a, b, c}.
+// At most 20 elements are shown; larger sets end with a suffix noting how many were left out.
+func (s *Set[T]) String() string {
+	return formatSet(len(s.list), s.All())
+}
+
+// formatSet formats the n elements yielded by elements as snapset{a, b, c},
+// showing at most maxStringElements of them.
+func formatSet[T any](n int, elements iter.Seq[T]) string {
+	var b strings.Builder
+	b.WriteString("snapset{")
+	i := 0
+	for element := range elements {
+		if i == maxStringElements {
+			fmt.Fprintf(&b, ", …(+%d more)", n-maxStringElements)
+			break
+		}
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprint(&b, element)
+		i++
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// GetRandomN returns up to n distinct random elements from the set, sampled without replacement.
+// If n exceeds the number of elements, every element is returned in random order.
+// It runs a partial Fisher–Yates shuffle over the list indices, tracking swapped indices in a
+// small map instead of permuting the list, so the set itself is never modified.
+func (s *Set[T]) GetRandomN(n int) []T {
+	n = max(0, min(n, len(s.list)))
+	out := make([]T, n)
+
+	// swapped holds the indices displaced by the virtual shuffle; absent keys map to themselves
+	swapped := make(map[int]int, n)
+	for i := 0; i < n; i++ {
+		j := i + s.rand.Intn(len(s.list)-i)
+
+		pickIdx, ok := swapped[j]
+		if !ok {
+			pickIdx = j
+		}
+		slotIdx, ok := swapped[i]
+		if !ok {
+			slotIdx = i
+		}
+
+		swapped[j] = slotIdx
+		out[i] = s.list[pickIdx]
+	}
+	return out
+}
+
+// Cap returns the capacity of the backing slice, which is the number of elements
+// the set can hold before the slice has to grow. Compare it with Len to decide
+// whether a call to Compact is worthwhile.
+func (s *Set[T]) Cap() int {
+	return cap(s.list)
+}
+
+// Compact reallocates the backing slice so that its capacity matches the number of elements,
+// and rebuilds the bucket map if it was sized for more entries than the set now holds,
+// releasing the memory left oversized by heavy deletion. Element indices are unchanged.
+// It runs in linear time and is a no-op if the set is already compact.
+func (s *Set[T]) Compact() {
+	if cap(s.list) > len(s.list) {
+		s.list = slices.Clip(slices.Clone(s.list))
+	}
+	if s.bucketHint > len(s.list) {
+		s.rebuildBucket(len(s.list))
+	}
+}
+
+// Grow ensures that at least n more elements can be inserted without reallocating the backing slice.
+// If the bucket map was allocated with a smaller size hint than Len()+n, it is rebuilt with a larger
+// hint so the inserts do not trigger incremental rehashing either; the rebuild runs in linear time.
+// On a bounded set, room is reserved only up to the bound.
+// It is a no-op if n is not positive.
+func (s *Set[T]) Grow(n int) {
+	if n <= 0 {
+		return
+	}
+
+	need := len(s.list) + n
+	if s.maxSize > 0 {
+		// A bounded set never needs room beyond its bound
+		need = min(need, s.maxSize)
+		if need > cap(s.list) {
+			list := make([]T, len(s.list), need)
+			copy(list, s.list)
+			s.list = list
+		}
+	} else {
+		s.list = slices.Grow(s.list, n)
+	}
+
+	if need > s.bucketHint {
+		s.rebuildBucket(need)
+	}
+}
+
+// Filter returns a new set containing only the elements for which pred returns true.
+// The result is pre-sized to the length of the receiver, which is left unchanged.
+func (s *Set[T]) Filter(pred func(T) bool) SnapSet[T] {
+	out := s.derive(len(s.list))
+	for _, element := range s.list {
+		if pred(element) {
+			out.Insert(element)
+		}
+	}
+	return out
+}
+
+// Partition returns two new sets: the elements for which pred returns true, and the rest.
+// It evaluates pred once per element; both results are pre-sized to the length of the receiver,
+// which is left unchanged.
+func (s *Set[T]) Partition(pred func(T) bool) (matched, unmatched SnapSet[T]) {
+	in := s.derive(len(s.list))
+	out := s.derive(len(s.list))
+	for _, element := range s.list {
+		if pred(element) {
+			in.Insert(element)
+		} else {
+			out.Insert(element)
+		}
+	}
+	return in, out
+}
+
+// Count returns the number of elements for which pred returns true.
+// Unlike Filter, it does not allocate a result set.
+func (s *Set[T]) Count(pred func(T) bool) int {
+	n := 0
+	for _, element := range s.list {
+		if pred(element) {
+			n++
+		}
+	}
+	return n
+}
+
+// Any reports whether at least one element satisfies pred.
+// It returns true at the first match, and false for an empty set.
+func (s *Set[T]) Any(pred func(T) bool) bool {
+	for _, element := range s.list {
+		if pred(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// Every reports whether every element satisfies pred.
+// It returns false at the first element that does not match, and true for an empty set.
+// It is named Every because All already returns the set's iterator.
+func (s *Set[T]) Every(pred func(T) bool) bool {
+	for _, element := range s.list {
+		if !pred(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// RemoveIf deletes every element for which pred returns true and returns the number of elements removed.
+// The list is scanned from the end: removing the element at i swaps in the last element,
+// which has already been visited, so no element is skipped or checked twice.
+func (s *Set[T]) RemoveIf(pred func(T) bool) int {
+	removed := 0
+	for i := len(s.list) - 1; i >= 0; i-- {
+		if pred(s.list[i]) {
+			s.removeAt(i)
+			removed++
+		}
+	}
+	return removed
+}
+
+// IterateSafe calls fn for each element present when it is called, and removes the element
+// right away if fn returns false. It walks a snapshot of the list rather than the list itself,
+// so the swap-deletes never cause an element to be visited twice or skipped.
+// Since fn sees a snapshot, it may modify the set itself: elements it inserts are not visited,
+// and elements it deletes are still passed to later calls if they were in the snapshot.
+func (s *Set[T]) IterateSafe(fn func(T) (keep bool)) {
+	for _, element := range s.ToSlice() {
+		if fn(element) {
+			continue
+		}
+		if idx, ok := s.bucket[element]; ok {
+			s.removeAt(idx)
+		}
+	}
+}
+
+// Merge inserts every element of other into the set in place, like an in-place Union,
+// and returns the number of elements that were newly added.
+// Apart from growing the set's own storage, it allocates nothing.
+func (s *Set[T]) Merge(other SnapSet[T]) int {
+	added := 0
+	other.ForEach(func(element T) bool {
+		if !s.Exists(element) {
+			s.Insert(element)
+			added++
+		}
+		return true
+	})
+	return added
+}
+
+// RetainAll removes every element that is not present in other, like an in-place Intersection,
+// and returns the number of elements removed.
+func (s *Set[T]) RetainAll(other SnapSet[T]) int {
+	return s.RemoveIf(func(element T) bool {
+		return !other.Exists(element)
+	})
+}
+
+// RemoveAll removes every element of other from the set, like an in-place Difference,
+// and returns the number of elements removed.
+// The elements of other are copied before deleting, so other may be the set itself,
+// or a wrapper such as OrderedSet around it; the cost is proportional to other's length.
+func (s *Set[T]) RemoveAll(other SnapSet[T]) int {
+	return s.DeleteMany(other.ToSlice()...)
+}
+
+// Shuffle randomly permutes the internal list using the set's generator and updates
+// the bucket map to match. A subsequent ToSlice, ForEach or All then visits every element
+// exactly once in random order, which is cheaper than GetRandomN(Len()).
+// Indices obtained before the call are invalidated.
+func (s *Set[T]) Shuffle() {
+	s.own()
+	s.rand.Shuffle(len(s.list), func(i, j int) {
+		s.list[i], s.list[j] = s.list[j], s.list[i]
+		s.swapped(i, j)
+	})
+	for idx, element := range s.list {
+		s.bucket[element] = idx
+	}
+}
+
+// DrainRandom returns an iterator that removes a random element and yields it,
+// repeating until the set is empty, for processing a worklist in random order.
+// Each element is removed before it is yielded, so breaking out of the loop
+// leaves only the elements not yet yielded in the set.
+// The loop body may insert new elements, which are then drained as well.
+func (s *Set[T]) DrainRandom() iter.Seq[T] {
+	return drainRandom(s.Pop)
+}
+
+// drainRandom yields the elements returned by pop until it reports that none are left.
+func drainRandom[T any](pop func() (T, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			element, ok := pop()
+			if !ok || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// AllIndexed returns an iterator that yields every element of the set together with its index
+// in the internal list, for use with range-over-func loops.
+// Indices are only meaningful within a single iteration that does not delete from the set,
+// since Delete moves elements between indices.
+func (s *Set[T]) AllIndexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for idx, element := range s.list {
+			if !yield(idx, element) {
+				return
+			}
+		}
+	}
+}
+
+// PopN removes up to n random elements from the set and returns them.
+// If fewer than n elements remain, all of them are removed and returned.
+// Rather than calling Pop n times, it shuffles n random elements to the tail of the list,
+// then truncates the list and removes them from the bucket map in a single pass.
+func (s *Set[T]) PopN(n int) []T {
+	n = max(0, min(n, len(s.list)))
+	keep := len(s.list) - n
+	s.own()
+
+	// Move a random element from the remaining prefix into each tail slot
+	for end := len(s.list) - 1; end >= keep; end-- {
+		idx := s.rand.Intn(end + 1)
+		s.list[idx], s.list[end] = s.list[end], s.list[idx]
+		s.bucket[s.list[idx]] = idx
+		s.swapped(idx, end)
+	}
+
+	out := make([]T, n)
+	copy(out, s.list[keep:])
+	for _, element := range out {
+		delete(s.bucket, element)
+	}
+
+	// Truncate the list once for the whole batch
+	clear(s.list[keep:])
+	s.list = s.list[:keep]
+
+	// Update the current index if the last inserted element was popped
+	if s.currIdx >= keep {
+		s.currIdx = -1
+	}
+	s.deletes += uint64(n)
+
+	s.maybeShrink()
+	for _, element := range out {
+		s.deleted(element)
+	}
+	return out
+}
+
+// IsDisjoint reports whether the set and other have no elements in common.
+// It iterates over the smaller of the two sets, checks membership in the larger one,
+// and returns false as soon as a shared element is found.
+func (s *Set[T]) IsDisjoint(other SnapSet[T]) bool {
+	if len(s.list) <= other.Len() {
+		for _, element := range s.list {
+			if other.Exists(element) {
+				return false
+			}
+		}
+		return true
+	}
+
+	disjoint := true
+	other.ForEach(func(element T) bool {
+		disjoint = !s.Exists(element)
+		return disjoint
+	})
+	return disjoint
+}
+
+// IntersectionCount returns the number of elements present in both the set and other.
+// Like IsDisjoint, it iterates over the smaller of the two sets and checks membership
+// in the larger one, without allocating an intersection set.
+func (s *Set[T]) IntersectionCount(other SnapSet[T]) int {
+	shared := 0
+	if len(s.list) <= other.Len() {
+		for _, element := range s.list {
+			if other.Exists(element) {
+				shared++
+			}
+		}
+		return shared
+	}
+
+	other.ForEach(func(element T) bool {
+		if s.Exists(element) {
+			shared++
+		}
+		return true
+	})
+	return shared
+}
+
+// GetRandomExcept returns a random element of the set that is not present in exclude, and true.
+// If every element is excluded, it returns the zero value of T and false.
+// When exclude is small relative to the set, it retries random picks a bounded number of times.
+// Otherwise, or if those picks all hit excluded elements, it falls back to a single scan
+// that samples uniformly among the remaining elements, so it never loops indefinitely.
+func (s *Set[T]) GetRandomExcept(exclude SnapSet[T]) (T, bool) {
+	if len(s.list) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	// Rejection sampling is cheap while most elements are eligible
+	if exclude.Len() < len(s.list)/2 {
+		for i := 0; i < maxExceptTries; i++ {
+			element := s.list[s.rand.Intn(len(s.list))]
+			if !exclude.Exists(element) {
+				return element, true
+			}
+		}
+	}
+
+	// Reservoir-sample a single element among the eligible ones
+	var pick T
+	eligible := 0
+	for _, element := range s.list {
+		if exclude.Exists(element) {
+			continue
+		}
+		eligible++
+		if s.rand.Intn(eligible) == 0 {
+			pick = element
+		}
+	}
+	return pick, eligible > 0
+}
+
+// GetRandomWithReplacement returns n random elements sampled with replacement,
+// so the same element may appear more than once, as needed for Monte Carlo sampling.
+// The result is allocated once up front. It returns an empty slice if the set is empty or n is not positive.
+func (s *Set[T]) GetRandomWithReplacement(n int) []T {
+	if len(s.list) == 0 || n <= 0 {
+		return []T{}
+	}
+
+	out := make([]T, n)
+	for i := range out {
+		out[i] = s.list[s.rand.Intn(len(s.list))]
+	}
+	return out
+}
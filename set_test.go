@@ -1,6 +1,13 @@
 package snapset_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/snapset"
@@ -135,3 +142,1671 @@ func TestGetRandom(t *testing.T) {
 		}
 	}
 }
+
+// TestLen checks the Len method.
+func TestLen(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	// A new set is empty
+	if s.Len() != 0 {
+		t.Errorf("Expected length 0, got %d", s.Len())
+	}
+
+	// Insert elements
+	s.Insert(1)
+	s.Insert(2)
+	s.Insert(3)
+	if s.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", s.Len())
+	}
+
+	// Delete an element
+	s.Delete(2)
+	if s.Len() != 2 {
+		t.Errorf("Expected length 2 after deletion, got %d", s.Len())
+	}
+}
+
+// TestGetRandomOK checks the GetRandomOK method.
+func TestGetRandomOK(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	// An empty set yields the zero value and false
+	val, ok := s.GetRandomOK()
+	if ok || val != 0 {
+		t.Errorf("Expected (0, false) on empty set, got (%d, %t)", val, ok)
+	}
+
+	// Insert an element
+	s.Insert(42)
+	val, ok = s.GetRandomOK()
+	if !ok || val != 42 {
+		t.Errorf("Expected (42, true), got (%d, %t)", val, ok)
+	}
+
+	// Drain the set again
+	s.Delete(42)
+	if _, ok = s.GetRandomOK(); ok {
+		t.Errorf("Expected false after draining the set")
+	}
+}
+
+// TestInsertDuplicate checks that inserting an existing element is idempotent.
+func TestInsertDuplicate(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	// Insert the same element twice
+	first := s.Insert(10)
+	second := s.Insert(10)
+	if first != second {
+		t.Errorf("Expected duplicate insert to return index %d, got %d", first, second)
+	}
+
+	if s.Len() != 1 {
+		t.Errorf("Expected length 1 after duplicate insert, got %d", s.Len())
+	}
+
+	// Deleting the element must leave no dangling copy behind
+	s.Delete(10)
+	if _, ok := s.GetRandomOK(); ok {
+		t.Errorf("Expected empty set after deleting the only element")
+	}
+}
+
+// TestClear checks the Clear method.
+func TestClear(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	// Insert elements
+	s.Insert(1)
+	s.Insert(2)
+	s.Insert(3)
+
+	// Clear the set
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Expected length 0 after Clear, got %d", s.Len())
+	}
+
+	if s.Exists(1) || s.Exists(2) || s.Exists(3) {
+		t.Errorf("No element should exist after Clear")
+	}
+
+	// The set should be reusable
+	idx := s.Insert(4)
+	if idx != 0 {
+		t.Errorf("Expected index 0 after Clear, got %d", idx)
+	}
+
+	if !s.Exists(4) || s.Len() != 1 {
+		t.Errorf("Element 4 should exist after reinsertion")
+	}
+}
+
+// TestToSlice checks the ToSlice method.
+func TestToSlice(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	// An empty set yields an empty slice
+	if got := s.ToSlice(); len(got) != 0 {
+		t.Errorf("Expected empty slice, got %v", got)
+	}
+
+	// Insert elements
+	s.Insert(1)
+	s.Insert(2)
+	s.Insert(3)
+
+	got := s.ToSlice()
+	if len(got) != s.Len() {
+		t.Errorf("Expected %d elements, got %d", s.Len(), len(got))
+	}
+
+	// Verify every element is present exactly once
+	seen := make(map[int]bool)
+	for _, val := range got {
+		if seen[val] {
+			t.Errorf("Element %d returned more than once", val)
+		}
+		seen[val] = true
+	}
+	for _, val := range []int{1, 2, 3} {
+		if !seen[val] {
+			t.Errorf("Element %d missing from ToSlice", val)
+		}
+	}
+
+	// Mutating the returned slice must not affect the set
+	got[0] = 100
+	if s.Exists(100) {
+		t.Errorf("Mutating the returned slice should not affect the set")
+	}
+}
+
+// TestClone checks the Clone method.
+func TestClone(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	// Insert elements
+	s.Insert(1)
+	s.Insert(2)
+
+	c := s.Clone()
+	if c.Len() != s.Len() {
+		t.Errorf("Expected clone length %d, got %d", s.Len(), c.Len())
+	}
+
+	if !c.Exists(1) || !c.Exists(2) {
+		t.Errorf("Elements 1 and 2 should exist in the clone")
+	}
+
+	// Mutating the clone must not affect the original
+	c.Insert(3)
+	c.Delete(1)
+	if s.Len() != 2 {
+		t.Errorf("Expected original length 2, got %d", s.Len())
+	}
+
+	if s.Exists(3) || !s.Exists(1) {
+		t.Errorf("Original set should not observe mutations of the clone")
+	}
+}
+
+// TestIsSubset checks the IsSubset and IsSuperset methods.
+func TestIsSubset(t *testing.T) {
+	required := snapset.New[string](snapset.DefaultBucketSize)
+	granted := snapset.New[string](snapset.DefaultBucketSize)
+
+	// The empty set is a subset of any set
+	if !required.IsSubset(granted) {
+		t.Errorf("Empty set should be a subset of any set")
+	}
+
+	// Insert elements
+	required.Insert("read")
+	required.Insert("write")
+	granted.Insert("read")
+	granted.Insert("write")
+	granted.Insert("admin")
+
+	if !required.IsSubset(granted) {
+		t.Errorf("Required set should be a subset of granted set")
+	}
+
+	if !granted.IsSuperset(required) {
+		t.Errorf("Granted set should be a superset of required set")
+	}
+
+	if granted.IsSubset(required) || required.IsSuperset(granted) {
+		t.Errorf("Granted set should not be a subset of required set")
+	}
+
+	// A missing element breaks the relation
+	required.Insert("delete")
+	if required.IsSubset(granted) {
+		t.Errorf("Required set should not be a subset once it has an extra element")
+	}
+}
+
+// TestEqual checks the Equal method.
+func TestEqual(t *testing.T) {
+	a := snapset.New[int](snapset.DefaultBucketSize)
+	b := snapset.New[int](snapset.DefaultBucketSize)
+
+	// Two empty sets are equal
+	if !a.Equal(b) {
+		t.Errorf("Empty sets should be equal")
+	}
+
+	// Insert the same elements in different orders
+	a.Insert(1)
+	a.Insert(2)
+	a.Insert(3)
+	b.Insert(3)
+	b.Insert(1)
+	b.Insert(2)
+
+	if !a.Equal(b) || !b.Equal(a) {
+		t.Errorf("Sets with the same elements should be equal")
+	}
+
+	// Same length but different contents
+	b.Delete(3)
+	b.Insert(4)
+	if a.Equal(b) {
+		t.Errorf("Sets with different elements should not be equal")
+	}
+
+	// Different lengths
+	b.Delete(4)
+	if a.Equal(b) {
+		t.Errorf("Sets with different lengths should not be equal")
+	}
+}
+
+// TestNewWithSource checks that sets sharing a seed make identical random selections.
+func TestNewWithSource(t *testing.T) {
+	a := snapset.NewWithSource[int](snapset.DefaultBucketSize, rand.NewSource(42))
+	b := snapset.NewWithSource[int](snapset.DefaultBucketSize, rand.NewSource(42))
+
+	// Insert the same elements in the same order
+	for i := 0; i < 10; i++ {
+		a.Insert(i)
+		b.Insert(i)
+	}
+
+	for i := 0; i < 100; i++ {
+		if va, vb := a.GetRandom(), b.GetRandom(); va != vb {
+			t.Fatalf("Draw %d differs: %d != %d", i, va, vb)
+		}
+	}
+}
+
+// TestPop checks the Pop method.
+func TestPop(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	// Pop from an empty set
+	if _, ok := s.Pop(); ok {
+		t.Errorf("Should not be able to pop from an empty set")
+	}
+
+	// Insert elements
+	s.Insert(1)
+	s.Insert(2)
+	s.Insert(3)
+
+	// Pop every element
+	popped := make(map[int]bool)
+	for i := 0; i < 3; i++ {
+		val, ok := s.Pop()
+		if !ok {
+			t.Fatalf("Failed to pop from a non-empty set")
+		}
+		if popped[val] {
+			t.Errorf("Element %d popped twice", val)
+		}
+		if s.Exists(val) {
+			t.Errorf("Popped element %d should no longer exist", val)
+		}
+		popped[val] = true
+
+		if s.Len() != 2-i {
+			t.Errorf("Expected length %d, got %d", 2-i, s.Len())
+		}
+	}
+
+	if _, ok := s.Pop(); ok {
+		t.Errorf("Should not be able to pop from a drained set")
+	}
+}
+
+// TestInsertMany checks the InsertMany method.
+func TestInsertMany(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	s.Insert(1)
+
+	// Insert a batch containing an existing element and a duplicate
+	added := s.InsertMany(1, 2, 3, 3, 4)
+	if added != 3 {
+		t.Errorf("Expected 3 newly added elements, got %d", added)
+	}
+
+	if s.Len() != 4 {
+		t.Errorf("Expected length 4, got %d", s.Len())
+	}
+
+	for _, val := range []int{1, 2, 3, 4} {
+		if !s.Exists(val) {
+			t.Errorf("Element %d should exist after InsertMany", val)
+		}
+	}
+
+	// An empty batch adds nothing
+	if added = s.InsertMany(); added != 0 {
+		t.Errorf("Expected 0 newly added elements, got %d", added)
+	}
+}
+
+// TestDeleteMany checks the DeleteMany method.
+func TestDeleteMany(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	s.InsertMany(1, 2, 3, 4, 5)
+
+	// Delete a batch containing an absent element and a duplicate
+	removed := s.DeleteMany(2, 4, 4, 6)
+	if removed != 2 {
+		t.Errorf("Expected 2 removed elements, got %d", removed)
+	}
+
+	if s.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", s.Len())
+	}
+
+	if s.Exists(2) || s.Exists(4) {
+		t.Errorf("Elements 2 and 4 should not exist after DeleteMany")
+	}
+
+	// The remaining elements must still be consistent
+	for _, val := range []int{1, 3, 5} {
+		if !s.Exists(val) {
+			t.Errorf("Element %d should still exist", val)
+		}
+		if _, ok := s.Delete(val); !ok {
+			t.Errorf("Failed to delete remaining element %d", val)
+		}
+	}
+}
+
+// TestForEach checks the ForEach method.
+func TestForEach(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	s.InsertMany(1, 2, 3, 4)
+
+	// Visit every element
+	visited := make(map[int]bool)
+	s.ForEach(func(val int) bool {
+		visited[val] = true
+		return true
+	})
+
+	if len(visited) != 4 {
+		t.Errorf("Expected 4 visited elements, got %d", len(visited))
+	}
+
+	// Stop after the first element
+	calls := 0
+	s.ForEach(func(int) bool {
+		calls++
+		return false
+	})
+
+	if calls != 1 {
+		t.Errorf("Expected iteration to stop after 1 call, got %d", calls)
+	}
+}
+
+// TestAll checks the All iterator.
+func TestAll(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	s.InsertMany(3, 1, 2)
+
+	// Collect every element through range-over-func
+	var got []int
+	for val := range s.All() {
+		got = append(got, val)
+	}
+
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+
+	// Breaking out of the loop stops the iteration
+	count := 0
+	for range s.All() {
+		count++
+		break
+	}
+
+	if count != 1 {
+		t.Errorf("Expected iteration to stop after 1 element, got %d", count)
+	}
+}
+
+// TestNewFromSlice checks the NewFromSlice constructor.
+func TestNewFromSlice(t *testing.T) {
+	s := snapset.NewFromSlice([]string{"a", "b", "a", "c", "b"})
+
+	if s.Len() != 3 {
+		t.Errorf("Expected length 3 after deduplication, got %d", s.Len())
+	}
+
+	for _, val := range []string{"a", "b", "c"} {
+		if !s.Exists(val) {
+			t.Errorf("Element %q should exist", val)
+		}
+	}
+
+	// A nil slice yields an empty set
+	if empty := snapset.NewFromSlice[int](nil); empty.Len() != 0 {
+		t.Errorf("Expected empty set from nil slice, got length %d", empty.Len())
+	}
+}
+
+// TestString checks the String method.
+func TestString(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	// An empty set
+	if got := fmt.Sprint(s); got != "snapset{}" {
+		t.Errorf("Expected snapset{}, got %s", got)
+	}
+
+	// Insert elements in a known order
+	s.InsertMany(1, 2, 3)
+	if got := fmt.Sprint(s); got != "snapset{1, 2, 3}" {
+		t.Errorf("Expected snapset{1, 2, 3}, got %s", got)
+	}
+
+	// Large sets are truncated
+	for i := 4; i <= 25; i++ {
+		s.Insert(i)
+	}
+	if got := fmt.Sprint(s); !strings.HasSuffix(got, ", 20, …(+5 more)}") {
+		t.Errorf("Expected truncated output, got %s", got)
+	}
+}
+
+// TestGetRandomN checks the GetRandomN method.
+func TestGetRandomN(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	// Sampling from an empty set yields nothing
+	if got := s.GetRandomN(3); len(got) != 0 {
+		t.Errorf("Expected no elements from an empty set, got %v", got)
+	}
+
+	for i := 0; i < 10; i++ {
+		s.Insert(i)
+	}
+
+	// Samples must contain distinct elements of the set
+	for round := 0; round < 100; round++ {
+		got := s.GetRandomN(4)
+		if len(got) != 4 {
+			t.Fatalf("Expected 4 elements, got %d", len(got))
+		}
+
+		seen := make(map[int]bool)
+		for _, val := range got {
+			if seen[val] {
+				t.Fatalf("Element %d sampled twice in %v", val, got)
+			}
+			if !s.Exists(val) {
+				t.Fatalf("Sampled element %d is not in the set", val)
+			}
+			seen[val] = true
+		}
+	}
+
+	// Requests larger than the set are capped at Len
+	got := s.GetRandomN(100)
+	slices.Sort(got)
+	if !slices.Equal(got, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Expected every element once, got %v", got)
+	}
+
+	// The set itself must not be modified
+	if s.Len() != 10 {
+		t.Errorf("Expected length 10, got %d", s.Len())
+	}
+
+	if got := s.GetRandomN(-1); len(got) != 0 {
+		t.Errorf("Expected no elements for negative n, got %v", got)
+	}
+}
+
+// TestCompact checks the Compact and Cap methods.
+func TestCompact(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	for i := 0; i < 1000; i++ {
+		s.Insert(i)
+	}
+
+	// Delete most elements
+	for i := 0; i < 990; i++ {
+		s.Delete(i)
+	}
+
+	if s.Cap() < 1000 {
+		t.Errorf("Expected capacity of at least 1000 before Compact, got %d", s.Cap())
+	}
+
+	s.Compact()
+	if s.Cap() != s.Len() {
+		t.Errorf("Expected capacity %d after Compact, got %d", s.Len(), s.Cap())
+	}
+
+	// The set must remain consistent
+	for i := 990; i < 1000; i++ {
+		if !s.Exists(i) {
+			t.Errorf("Element %d should still exist after Compact", i)
+		}
+	}
+	s.Insert(1000)
+	if _, ok := s.Delete(995); !ok || s.Len() != 10 {
+		t.Errorf("Set should remain usable after Compact")
+	}
+}
+
+// TestGrow checks the Grow method.
+func TestGrow(t *testing.T) {
+	s := snapset.New[int](0)
+	s.InsertMany(1, 2, 3)
+
+	s.Grow(100)
+	if s.Cap() < s.Len()+100 {
+		t.Errorf("Expected capacity of at least %d, got %d", s.Len()+100, s.Cap())
+	}
+
+	// Filling the reserved room must not reallocate the backing slice
+	capacity := s.Cap()
+	for i := 0; i < 100; i++ {
+		s.Insert(100 + i)
+	}
+
+	if s.Cap() != capacity {
+		t.Errorf("Expected capacity to stay %d, got %d", capacity, s.Cap())
+	}
+
+	// Existing elements survive the growth
+	for _, val := range []int{1, 2, 3, 150} {
+		if !s.Exists(val) {
+			t.Errorf("Element %d should exist after Grow", val)
+		}
+	}
+
+	// Non-positive values are ignored
+	s.Grow(-1)
+	if s.Len() != 103 {
+		t.Errorf("Expected length 103, got %d", s.Len())
+	}
+}
+
+// TestFilter checks the Filter method.
+func TestFilter(t *testing.T) {
+	s := snapset.NewFromSlice([]int{1, 2, 3, 4, 5, 6})
+
+	even := s.Filter(func(val int) bool { return val%2 == 0 })
+	if even.Len() != 3 {
+		t.Errorf("Expected 3 even elements, got %d", even.Len())
+	}
+
+	for _, val := range []int{2, 4, 6} {
+		if !even.Exists(val) {
+			t.Errorf("Element %d should exist in the filtered set", val)
+		}
+	}
+
+	// The receiver is unchanged
+	if s.Len() != 6 {
+		t.Errorf("Expected original length 6, got %d", s.Len())
+	}
+
+	// A predicate that never matches yields an empty set
+	if none := s.Filter(func(int) bool { return false }); none.Len() != 0 {
+		t.Errorf("Expected an empty set, got length %d", none.Len())
+	}
+}
+
+// TestRemoveIf checks the RemoveIf method.
+func TestRemoveIf(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	for i := 0; i < 100; i++ {
+		s.Insert(i)
+	}
+
+	// Remove every multiple of three
+	removed := s.RemoveIf(func(val int) bool { return val%3 == 0 })
+	if removed != 34 {
+		t.Errorf("Expected 34 removed elements, got %d", removed)
+	}
+
+	if s.Len() != 66 {
+		t.Errorf("Expected length 66, got %d", s.Len())
+	}
+
+	for i := 0; i < 100; i++ {
+		if s.Exists(i) == (i%3 == 0) {
+			t.Errorf("Unexpected membership for element %d", i)
+		}
+	}
+
+	// Remove everything that is left
+	if removed = s.RemoveIf(func(int) bool { return true }); removed != 66 || s.Len() != 0 {
+		t.Errorf("Expected all 66 elements removed, got %d", removed)
+	}
+}
+
+// TestIndexOf checks the IndexOf method.
+func TestIndexOf(t *testing.T) {
+	s := snapset.New[string](snapset.DefaultBucketSize)
+
+	if _, ok := s.IndexOf("a"); ok {
+		t.Errorf("Absent element should have no index")
+	}
+
+	// Indices match those returned by Insert
+	for i, val := range []string{"a", "b", "c"} {
+		if idx := s.Insert(val); idx != i {
+			t.Errorf("Expected index %d, got %d", i, idx)
+		}
+	}
+
+	if idx, ok := s.IndexOf("b"); !ok || idx != 1 {
+		t.Errorf("Expected (1, true), got (%d, %t)", idx, ok)
+	}
+
+	// Deleting moves the last element into the freed slot
+	s.Delete("a")
+	if idx, ok := s.IndexOf("c"); !ok || idx != 0 {
+		t.Errorf("Expected c to move to index 0, got (%d, %t)", idx, ok)
+	}
+
+	if _, ok := s.IndexOf("a"); ok {
+		t.Errorf("Deleted element should have no index")
+	}
+}
+
+// TestAt checks the At method.
+func TestAt(t *testing.T) {
+	s := snapset.New[string](snapset.DefaultBucketSize)
+	s.InsertMany("a", "b", "c")
+
+	// Every in-range index maps back to its element
+	for i := 0; i < s.Len(); i++ {
+		val, ok := s.At(i)
+		if !ok {
+			t.Fatalf("Expected an element at index %d", i)
+		}
+		if idx, _ := s.IndexOf(val); idx != i {
+			t.Errorf("Element %q at index %d reports index %d", val, i, idx)
+		}
+	}
+
+	// Out-of-range indices are rejected
+	for _, idx := range []int{-1, 3} {
+		if _, ok := s.At(idx); ok {
+			t.Errorf("Expected no element at index %d", idx)
+		}
+	}
+}
+
+// TestContainsAllAny checks the ContainsAll and ContainsAny methods.
+func TestContainsAllAny(t *testing.T) {
+	s := snapset.NewFromSlice([]string{"dark-mode", "beta"})
+
+	if !s.ContainsAll("dark-mode", "beta") {
+		t.Errorf("Set should contain all of its elements")
+	}
+
+	if s.ContainsAll("dark-mode", "legacy") {
+		t.Errorf("Set should not contain all when one element is missing")
+	}
+
+	if !s.ContainsAny("legacy", "beta") {
+		t.Errorf("Set should contain any when one element exists")
+	}
+
+	if s.ContainsAny("legacy", "alpha") {
+		t.Errorf("Set should not contain any of the missing elements")
+	}
+
+	// Empty argument lists
+	if !s.ContainsAll() || s.ContainsAny() {
+		t.Errorf("Expected ContainsAll() to be true and ContainsAny() to be false")
+	}
+}
+
+// TestMerge checks the Merge method.
+func TestMerge(t *testing.T) {
+	master := snapset.NewFromSlice([]int{1, 2})
+	shard := snapset.NewFromSlice([]int{2, 3, 4})
+
+	added := master.Merge(shard)
+	if added != 2 {
+		t.Errorf("Expected 2 newly added elements, got %d", added)
+	}
+
+	for _, val := range []int{1, 2, 3, 4} {
+		if !master.Exists(val) {
+			t.Errorf("Element %d should exist after Merge", val)
+		}
+	}
+
+	// The merged set is left unchanged
+	if shard.Len() != 3 {
+		t.Errorf("Expected shard length 3, got %d", shard.Len())
+	}
+
+	// Merging a set into itself adds nothing
+	if added = master.Merge(master); added != 0 {
+		t.Errorf("Expected 0 newly added elements, got %d", added)
+	}
+}
+
+// TestRetainAll checks the RetainAll method.
+func TestRetainAll(t *testing.T) {
+	candidates := snapset.NewFromSlice([]int{1, 2, 3, 4, 5, 6})
+
+	// Narrow the candidates with successive constraints
+	removed := candidates.RetainAll(snapset.NewFromSlice([]int{2, 3, 4, 5, 9}))
+	if removed != 2 {
+		t.Errorf("Expected 2 removed elements, got %d", removed)
+	}
+
+	removed = candidates.RetainAll(snapset.NewFromSlice([]int{1, 3, 5}))
+	if removed != 2 {
+		t.Errorf("Expected 2 removed elements, got %d", removed)
+	}
+
+	if candidates.Len() != 2 || !candidates.ContainsAll(3, 5) {
+		t.Errorf("Expected {3, 5}, got %v", candidates)
+	}
+
+	// Retaining against itself removes nothing
+	if removed = candidates.RetainAll(candidates); removed != 0 {
+		t.Errorf("Expected 0 removed elements, got %d", removed)
+	}
+}
+
+// TestRemoveAll checks in-place subtraction of another set.
+func TestRemoveAll(t *testing.T) {
+	available := snapset.NewFromSlice([]int{1, 2, 3, 4, 5})
+
+	// Subtract the reserved elements, including one that is not available
+	removed := available.RemoveAll(snapset.NewFromSlice([]int{2, 4, 9}))
+	if removed != 2 {
+		t.Errorf("Expected 2 removed elements, got %d", removed)
+	}
+
+	if available.Len() != 3 || !available.ContainsAll(1, 3, 5) {
+		t.Errorf("Expected {1, 3, 5}, got %v", available)
+	}
+
+	// Removing a set from itself empties it
+	if removed = available.RemoveAll(available); removed != 3 || available.Len() != 0 {
+		t.Errorf("Expected 3 removed elements and an empty set, got %d and %v", removed, available)
+	}
+}
+
+// TestDrainAndRefill checks that a set drained to empty behaves like a new set.
+func TestDrainAndRefill(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	// Delete down to empty from a single element
+	s.Insert(1)
+	if idx, ok := s.Delete(1); !ok || idx != 0 {
+		t.Errorf("Expected (0, true), got (%d, %t)", idx, ok)
+	}
+
+	if s.Len() != 0 {
+		t.Errorf("Expected empty set, got length %d", s.Len())
+	}
+
+	// Re-insert after draining
+	for round := 0; round < 3; round++ {
+		if idx := s.Insert(10); idx != 0 {
+			t.Errorf("Expected index 0 after refill, got %d", idx)
+		}
+		if idx := s.Insert(20); idx != 1 {
+			t.Errorf("Expected index 1 after refill, got %d", idx)
+		}
+
+		for i := 0; i < 20; i++ {
+			if val := s.GetRandom(); val != 10 && val != 20 {
+				t.Fatalf("Unexpected element %d returned by GetRandom", val)
+			}
+		}
+
+		s.Delete(20)
+		s.Delete(10)
+		if _, ok := s.GetRandomOK(); ok {
+			t.Errorf("Expected empty set after draining")
+		}
+	}
+
+	// Clear behaves the same way
+	s.InsertMany(1, 2, 3)
+	s.Clear()
+	if idx := s.Insert(4); idx != 0 || s.GetRandom() != 4 {
+		t.Errorf("Expected a single element at index 0 after Clear, got index %d", idx)
+	}
+}
+
+// TestGetRandomUnbiasedAfterDeletes checks that GetRandom stays uniform after many swap-deletes.
+func TestGetRandomUnbiasedAfterDeletes(t *testing.T) {
+	const (
+		n       = 100
+		samples = 200000
+	)
+
+	s := snapset.NewWithSource[int](n, rand.NewSource(7))
+	for i := 0; i < n; i++ {
+		s.Insert(i)
+	}
+
+	// Delete a random half of the elements
+	r := rand.New(rand.NewSource(11))
+	for _, val := range r.Perm(n)[:n/2] {
+		s.Delete(val)
+	}
+
+	counts := make(map[int]int)
+	for i := 0; i < samples; i++ {
+		counts[s.GetRandom()]++
+	}
+
+	// Only survivors may be returned, each with roughly equal frequency
+	if len(counts) != s.Len() {
+		t.Errorf("Expected %d distinct elements, got %d", s.Len(), len(counts))
+	}
+
+	expected := float64(samples) / float64(s.Len())
+	for val, count := range counts {
+		if !s.Exists(val) {
+			t.Errorf("Deleted element %d was returned by GetRandom", val)
+		}
+		if math.Abs(float64(count)-expected)/expected > 0.1 {
+			t.Errorf("Element %d returned %d times, expected about %.0f", val, count, expected)
+		}
+	}
+}
+
+// TestAllIndexed checks the AllIndexed iterator.
+func TestAllIndexed(t *testing.T) {
+	s := snapset.NewFromSlice([]string{"a", "b", "c"})
+	s.Delete("a")
+
+	count := 0
+	for idx, val := range s.AllIndexed() {
+		if got, _ := s.At(idx); got != val {
+			t.Errorf("Index %d yielded %q, but At returns %q", idx, val, got)
+		}
+		count++
+	}
+
+	if count != s.Len() {
+		t.Errorf("Expected %d pairs, got %d", s.Len(), count)
+	}
+}
+
+// TestPopN checks the PopN method.
+func TestPopN(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	for i := 0; i < 10; i++ {
+		s.Insert(i)
+	}
+
+	// Pop a batch
+	batch := s.PopN(4)
+	if len(batch) != 4 || s.Len() != 6 {
+		t.Errorf("Expected 4 popped and 6 remaining, got %d and %d", len(batch), s.Len())
+	}
+
+	seen := make(map[int]bool)
+	for _, val := range batch {
+		if seen[val] {
+			t.Errorf("Element %d popped twice", val)
+		}
+		if s.Exists(val) {
+			t.Errorf("Popped element %d should no longer exist", val)
+		}
+		seen[val] = true
+	}
+
+	// The remaining elements must be consistently indexed
+	for idx, val := range s.AllIndexed() {
+		if got, ok := s.IndexOf(val); !ok || got != idx {
+			t.Errorf("Element %d stored at %d but indexed at %d", val, idx, got)
+		}
+	}
+
+	// Asking for more than remains drains the set
+	if rest := s.PopN(100); len(rest) != 6 || s.Len() != 0 {
+		t.Errorf("Expected the remaining 6 elements, got %d", len(rest))
+	}
+
+	if rest := s.PopN(1); len(rest) != 0 {
+		t.Errorf("Expected nothing from an empty set, got %v", rest)
+	}
+}
+
+// TestCopyInto checks the CopyInto method.
+func TestCopyInto(t *testing.T) {
+	s := snapset.NewFromSlice([]int{1, 2, 3})
+
+	// A buffer large enough for every element
+	buf := make([]int, 5)
+	if n := s.CopyInto(buf); n != 3 {
+		t.Errorf("Expected 3 copied elements, got %d", n)
+	}
+
+	got := slices.Clone(buf[:3])
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+
+	// A buffer smaller than the set
+	small := make([]int, 2)
+	if n := s.CopyInto(small); n != 2 {
+		t.Errorf("Expected 2 copied elements, got %d", n)
+	}
+	for _, val := range small {
+		if !s.Exists(val) {
+			t.Errorf("Copied element %d is not in the set", val)
+		}
+	}
+
+	if n := s.CopyInto(nil); n != 0 {
+		t.Errorf("Expected 0 copied elements, got %d", n)
+	}
+}
+
+// TestIsDisjoint checks the IsDisjoint method.
+func TestIsDisjoint(t *testing.T) {
+	small := snapset.NewFromSlice([]int{1, 2})
+	large := snapset.NewFromSlice([]int{3, 4, 5, 6})
+
+	if !small.IsDisjoint(large) || !large.IsDisjoint(small) {
+		t.Errorf("Sets without shared elements should be disjoint")
+	}
+
+	// Share a single element
+	large.Insert(2)
+	if small.IsDisjoint(large) || large.IsDisjoint(small) {
+		t.Errorf("Sets sharing element 2 should not be disjoint")
+	}
+
+	// The empty set is disjoint with every set
+	if !snapset.New[int](0).IsDisjoint(large) {
+		t.Errorf("The empty set should be disjoint with any set")
+	}
+}
+
+// TestGetRandomExcept checks the GetRandomExcept method.
+func TestGetRandomExcept(t *testing.T) {
+	peers := snapset.NewFromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	// Exclude a small set: rejection sampling path
+	active := snapset.NewFromSlice([]int{1, 2})
+	for i := 0; i < 100; i++ {
+		val, ok := peers.GetRandomExcept(active)
+		if !ok || active.Exists(val) {
+			t.Fatalf("Expected a non-excluded element, got (%d, %t)", val, ok)
+		}
+	}
+
+	// Exclude all but one element: scanning path
+	active = snapset.NewFromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	for i := 0; i < 10; i++ {
+		if val, ok := peers.GetRandomExcept(active); !ok || val != 10 {
+			t.Fatalf("Expected (10, true), got (%d, %t)", val, ok)
+		}
+	}
+
+	// Exclude everything
+	if _, ok := peers.GetRandomExcept(peers); ok {
+		t.Errorf("Expected false when every element is excluded")
+	}
+
+	if _, ok := snapset.New[int](0).GetRandomExcept(active); ok {
+		t.Errorf("Expected false for an empty set")
+	}
+}
+
+// TestGetRandomFrom checks the GetRandomFrom method.
+func TestGetRandomFrom(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	if _, ok := s.GetRandomFrom(rand.New(rand.NewSource(1))); ok {
+		t.Errorf("Expected false for an empty set")
+	}
+
+	s.InsertMany(1, 2, 3, 4, 5)
+
+	// Generators with the same seed make the same picks
+	a := rand.New(rand.NewSource(99))
+	b := rand.New(rand.NewSource(99))
+	for i := 0; i < 50; i++ {
+		va, _ := s.GetRandomFrom(a)
+		vb, ok := s.GetRandomFrom(b)
+		if !ok || va != vb {
+			t.Fatalf("Draw %d differs: %d != %d", i, va, vb)
+		}
+	}
+}
+
+// TestShrinkAfterDeletes checks that a set stays consistent when its bucket map is rebuilt.
+func TestShrinkAfterDeletes(t *testing.T) {
+	s := snapset.New[int](0)
+	for i := 0; i < 10000; i++ {
+		s.Insert(i)
+	}
+
+	// Delete well below the automatic shrink threshold
+	for i := 0; i < 9950; i++ {
+		s.Delete(i)
+	}
+
+	if s.Len() != 50 {
+		t.Errorf("Expected length 50, got %d", s.Len())
+	}
+
+	// The bucket map was rebuilt once the set fell below a tenth of its peak of 10000
+	if hint := s.Stats().BucketHint; hint >= 1000 {
+		t.Errorf("Expected the bucket map to be rebuilt below 1000 entries, got a hint of %d", hint)
+	}
+
+	for idx, val := range s.AllIndexed() {
+		if got, ok := s.IndexOf(val); !ok || got != idx {
+			t.Errorf("Element %d stored at %d but indexed at %d", val, idx, got)
+		}
+	}
+
+	// The set keeps working after the rebuild
+	s.Compact()
+	if stats := s.Stats(); stats.BucketHint != 50 || stats.Cap != 50 {
+		t.Errorf("Expected Compact to size the set for 50 elements, got hint %d and capacity %d", stats.BucketHint, stats.Cap)
+	}
+	s.Insert(20000)
+	if _, ok := s.Delete(9999); !ok || !s.Exists(20000) || s.Len() != 50 {
+		t.Errorf("Set should remain usable after shrinking")
+	}
+}
+
+// TestGetRandomWithReplacement checks the GetRandomWithReplacement method.
+func TestGetRandomWithReplacement(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	if got := s.GetRandomWithReplacement(5); len(got) != 0 {
+		t.Errorf("Expected no elements from an empty set, got %v", got)
+	}
+
+	s.InsertMany(1, 2)
+
+	// More samples than elements forces repeats
+	got := s.GetRandomWithReplacement(50)
+	if len(got) != 50 {
+		t.Fatalf("Expected 50 samples, got %d", len(got))
+	}
+
+	counts := make(map[int]int)
+	for _, val := range got {
+		if !s.Exists(val) {
+			t.Errorf("Sampled element %d is not in the set", val)
+		}
+		counts[val]++
+	}
+
+	if counts[1] == 0 || counts[2] == 0 {
+		t.Errorf("Expected both elements to be sampled, got %v", counts)
+	}
+}
+
+// TestInsertIfAbsent checks the InsertIfAbsent method.
+func TestInsertIfAbsent(t *testing.T) {
+	s := snapset.New[string](snapset.DefaultBucketSize)
+
+	if idx, added := s.InsertIfAbsent("a"); !added || idx != 0 {
+		t.Errorf("Expected (0, true), got (%d, %t)", idx, added)
+	}
+
+	if idx, added := s.InsertIfAbsent("b"); !added || idx != 1 {
+		t.Errorf("Expected (1, true), got (%d, %t)", idx, added)
+	}
+
+	// An existing element reports its current index and false
+	if idx, added := s.InsertIfAbsent("a"); added || idx != 0 {
+		t.Errorf("Expected (0, false), got (%d, %t)", idx, added)
+	}
+
+	if s.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", s.Len())
+	}
+}
+
+// TestReplace checks the Replace method.
+func TestReplace(t *testing.T) {
+	s := snapset.NewFromSlice([]string{"a", "b", "c"})
+	idx, _ := s.IndexOf("b")
+
+	if !s.Replace("b", "z") {
+		t.Fatalf("Failed to replace an existing element")
+	}
+
+	// The new element takes over the old index
+	if got, ok := s.IndexOf("z"); !ok || got != idx {
+		t.Errorf("Expected z at index %d, got (%d, %t)", idx, got, ok)
+	}
+
+	if s.Exists("b") || s.Len() != 3 {
+		t.Errorf("Element b should be gone and the length unchanged")
+	}
+
+	// Absent old element or existing new element
+	if s.Replace("missing", "y") {
+		t.Errorf("Should not replace an absent element")
+	}
+	if s.Replace("a", "c") {
+		t.Errorf("Should not replace with an element that already exists")
+	}
+	assertElements(t, s, "a", "z", "c")
+}
+
+// TestCount checks the Count method.
+func TestCount(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	if n := s.Count(func(int) bool { return true }); n != 0 {
+		t.Errorf("Expected 0 for an empty set, got %d", n)
+	}
+
+	for i := 1; i <= 10; i++ {
+		s.Insert(i)
+	}
+
+	if n := s.Count(func(val int) bool { return val > 7 }); n != 3 {
+		t.Errorf("Expected 3 elements above 7, got %d", n)
+	}
+}
+
+// TestAnyEvery checks the Any and Every methods.
+func TestAnyEvery(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	positive := func(val int) bool { return val > 0 }
+
+	// Empty set conventions
+	if s.Any(positive) || !s.Every(positive) {
+		t.Errorf("Expected Any to be false and Every to be true for an empty set")
+	}
+
+	s.InsertMany(1, 2, 3)
+	if !s.Any(positive) || !s.Every(positive) {
+		t.Errorf("Expected all elements to be positive")
+	}
+
+	s.Insert(-1)
+	if !s.Any(positive) || s.Every(positive) {
+		t.Errorf("Expected some but not all elements to be positive")
+	}
+
+	// Any stops at the first match
+	calls := 0
+	s.Any(func(int) bool {
+		calls++
+		return true
+	})
+	if calls != 1 {
+		t.Errorf("Expected Any to stop after 1 call, got %d", calls)
+	}
+}
+
+// TestSetRand checks that replacing the generator makes selection reproducible.
+func TestSetRand(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	for i := 0; i < 100; i++ {
+		s.Insert(i)
+	}
+
+	// Draw a sequence with a fixed seed
+	s.SetRand(rand.New(rand.NewSource(7)))
+	first := s.GetRandomN(10)
+
+	// Re-seed and draw again
+	s.SetRand(rand.New(rand.NewSource(7)))
+	second := s.GetRandomN(10)
+
+	if !slices.Equal(first, second) {
+		t.Errorf("Expected identical draws after re-seeding, got %v and %v", first, second)
+	}
+
+	// A nil generator is replaced with a fresh one
+	s.SetRand(nil)
+	if _, ok := s.GetRandomOK(); !ok {
+		t.Errorf("Expected GetRandomOK to succeed after SetRand(nil)")
+	}
+}
+
+// TestInsertFromChan checks that a channel is drained into the set.
+func TestInsertFromChan(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	s.Insert(1)
+
+	ch := make(chan int)
+	go func() {
+		for _, val := range []int{1, 2, 3, 2, 4} {
+			ch <- val
+		}
+		close(ch)
+	}()
+
+	if added := s.InsertFromChan(ch); added != 3 {
+		t.Errorf("Expected 3 new elements, got %d", added)
+	}
+
+	if s.Len() != 4 {
+		t.Errorf("Expected length 4, got %d", s.Len())
+	}
+}
+
+// TestStream checks that Stream sends every element and stops on cancellation.
+func TestStream(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	s.InsertMany(1, 2, 3)
+
+	// Receive all elements
+	var got []int
+	for val := range s.Stream(context.Background()) {
+		got = append(got, val)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+
+	// A cancelled context closes the channel early
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := s.Stream(ctx)
+	<-ch
+	cancel()
+	for range ch {
+	}
+}
+
+// TestPartition checks that Partition splits the set without modifying it.
+func TestPartition(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	s.InsertMany(1, 2, 3, 4, 5)
+
+	even, odd := s.Partition(func(val int) bool { return val%2 == 0 })
+
+	if even.Len() != 2 || !even.ContainsAll(2, 4) {
+		t.Errorf("Expected matched set {2, 4}, got %v", even.ToSlice())
+	}
+	if odd.Len() != 3 || !odd.ContainsAll(1, 3, 5) {
+		t.Errorf("Expected unmatched set {1, 3, 5}, got %v", odd.ToSlice())
+	}
+
+	// Receiver is unchanged
+	if s.Len() != 5 {
+		t.Errorf("Expected receiver length 5, got %d", s.Len())
+	}
+}
+
+// TestMustGetRandom checks the panic message on an empty set.
+func TestMustGetRandom(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	s.Insert(7)
+
+	if val := s.MustGetRandom(); val != 7 {
+		t.Errorf("Expected 7, got %d", val)
+	}
+
+	s.Clear()
+	defer func() {
+		if r := recover(); r != "snapset: GetRandom on empty set" {
+			t.Errorf("Expected a descriptive panic, got %v", r)
+		}
+	}()
+	s.MustGetRandom()
+}
+
+// TestMissing checks that absent elements are reported in argument order.
+func TestMissing(t *testing.T) {
+	s := snapset.NewFromSlice([]string{"net", "os", "io"})
+
+	missing := s.Missing("fmt", "os", "sync", "io", "errors")
+	if !slices.Equal(missing, []string{"fmt", "sync", "errors"}) {
+		t.Errorf("Expected [fmt sync errors], got %v", missing)
+	}
+
+	// Nothing is missing
+	if missing = s.Missing("net", "io"); missing != nil {
+		t.Errorf("Expected nil, got %v", missing)
+	}
+}
+
+// TestIntersectionCount checks the shared element count in both iteration directions.
+func TestIntersectionCount(t *testing.T) {
+	small := snapset.NewFromSlice([]int{2, 4, 9})
+	large := snapset.NewFromSlice([]int{1, 2, 3, 4, 5, 6})
+
+	if n := small.IntersectionCount(large); n != 2 {
+		t.Errorf("Expected 2 shared elements, got %d", n)
+	}
+	if n := large.IntersectionCount(small); n != 2 {
+		t.Errorf("Expected 2 shared elements, got %d", n)
+	}
+
+	// No overlap with an empty set
+	if n := large.IntersectionCount(snapset.New[int](snapset.DefaultBucketSize)); n != 0 {
+		t.Errorf("Expected 0 shared elements, got %d", n)
+	}
+}
+
+// TestGetRandomIndexed checks that the returned index points at the returned element.
+func TestGetRandomIndexed(t *testing.T) {
+	s := snapset.New[string](snapset.DefaultBucketSize)
+
+	if _, _, ok := s.GetRandomIndexed(); ok {
+		t.Errorf("Expected GetRandomIndexed to fail on an empty set")
+	}
+
+	s.InsertMany("a", "b", "c")
+	for i := 0; i < 10; i++ {
+		val, idx, ok := s.GetRandomIndexed()
+		if !ok {
+			t.Fatalf("Expected GetRandomIndexed to succeed")
+		}
+		if at, _ := s.At(idx); at != val {
+			t.Errorf("Expected index %d to hold %s, got %s", idx, val, at)
+		}
+	}
+}
+
+// TestShuffle checks that Shuffle permutes the elements and keeps indices consistent.
+func TestShuffle(t *testing.T) {
+	s := snapset.NewWithSource[int](snapset.DefaultBucketSize, rand.NewSource(1))
+	for i := 0; i < 52; i++ {
+		s.Insert(i)
+	}
+	before := s.ToSlice()
+
+	s.Shuffle()
+	after := s.ToSlice()
+
+	if slices.Equal(before, after) {
+		t.Errorf("Expected the order to change after Shuffle")
+	}
+
+	// Every element is still present exactly once, at the index the set reports
+	for idx, val := range after {
+		if got, ok := s.IndexOf(val); !ok || got != idx {
+			t.Errorf("Expected %d at index %d, got %d", val, idx, got)
+		}
+	}
+
+	slices.Sort(after)
+	if !slices.Equal(before, after) {
+		t.Errorf("Expected the same elements after Shuffle")
+	}
+}
+
+// TestDrainRandom checks that DrainRandom removes every element it yields.
+func TestDrainRandom(t *testing.T) {
+	s := snapset.NewFromSlice([]int{1, 2, 3, 4, 5})
+
+	// Stop after two elements
+	var seen []int
+	for val := range s.DrainRandom() {
+		seen = append(seen, val)
+		if len(seen) == 2 {
+			break
+		}
+	}
+	if s.Len() != 3 || s.ContainsAny(seen...) {
+		t.Errorf("Expected 3 remaining elements without %v, got %v", seen, s)
+	}
+
+	// Drain the rest
+	for val := range s.DrainRandom() {
+		seen = append(seen, val)
+	}
+	slices.Sort(seen)
+	if s.Len() != 0 || !slices.Equal(seen, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected every element drained once, got %v", seen)
+	}
+}
+
+// TestExistsAll checks that membership results are aligned with the input.
+func TestExistsAll(t *testing.T) {
+	s := snapset.NewFromSlice([]int{1, 3, 5})
+
+	got := s.ExistsAll([]int{1, 2, 3, 4, 5, 1})
+	want := []bool{true, false, true, false, true, true}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if got = s.ExistsAll(nil); len(got) != 0 {
+		t.Errorf("Expected an empty result, got %v", got)
+	}
+}
+
+// TestLastInserted checks that the last inserted element is tracked through deletions.
+func TestLastInserted(t *testing.T) {
+	s := snapset.NewWithSource[int](snapset.DefaultBucketSize, rand.NewSource(1))
+
+	if _, ok := s.LastInserted(); ok {
+		t.Errorf("Expected no last inserted element for an empty set")
+	}
+
+	s.InsertMany(1, 2, 3)
+	s.Insert(1) // Re-inserting is a no-op
+
+	if val, ok := s.LastInserted(); !ok || val != 3 {
+		t.Errorf("Expected 3, got %d", val)
+	}
+
+	// Deleting another element moves 3 into the freed slot
+	s.Delete(1)
+	if val, ok := s.LastInserted(); !ok || val != 3 {
+		t.Errorf("Expected 3 after deleting 1, got %d", val)
+	}
+
+	// Shuffling keeps track of the element
+	s.InsertMany(4, 5, 6, 7)
+	s.Shuffle()
+	if val, ok := s.LastInserted(); !ok || val != 7 {
+		t.Errorf("Expected 7 after Shuffle, got %d", val)
+	}
+
+	// Replace counts as an insertion
+	s.Replace(2, 8)
+	if val, ok := s.LastInserted(); !ok || val != 8 {
+		t.Errorf("Expected 8 after Replace, got %d", val)
+	}
+
+	// Removing the element invalidates the result
+	s.Delete(8)
+	if _, ok := s.LastInserted(); ok {
+		t.Errorf("Expected no last inserted element after deleting it")
+	}
+
+	// PopN invalidates it only when the element is popped
+	s.Insert(9)
+	popped := s.PopN(3)
+	val, ok := s.LastInserted()
+	if slices.Contains(popped, 9) == ok || (ok && val != 9) {
+		t.Errorf("Expected 9 unless popped, got %d, %v after popping %v", val, ok, popped)
+	}
+}
+
+// TestLastInsertedTracking checks that the last inserted element is followed through
+// every kind of removal and replacement that moves or drops it.
+func TestLastInsertedTracking(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	s.InsertMany(1, 2, 3)
+
+	// Deleting an element in front of it swaps it into the freed slot
+	s.Delete(2)
+	if val, ok := s.LastInserted(); !ok || val != 3 {
+		t.Errorf("Expected 3 after swap-deleting 2, got %d, %v", val, ok)
+	}
+	if idx, _ := s.IndexOf(3); idx != 1 {
+		t.Errorf("Expected 3 to move to index 1, got %d", idx)
+	}
+
+	// Deleting it from the end of the list drops it
+	s.Delete(3)
+	if _, ok := s.LastInserted(); ok {
+		t.Errorf("Expected no last inserted element after deleting it")
+	}
+
+	// Deleting other elements does not bring back an earlier insertion
+	s.Delete(1)
+	if _, ok := s.LastInserted(); ok {
+		t.Errorf("Expected no last inserted element after deleting an older one")
+	}
+
+	// Replacing it counts the replacement as the last insertion
+	s.InsertMany(4, 5)
+	s.Replace(5, 6)
+	if val, ok := s.LastInserted(); !ok || val != 6 {
+		t.Errorf("Expected 6 after replacing 5, got %d, %v", val, ok)
+	}
+
+	// Replacing an older element makes the replacement the last insertion
+	s.Replace(4, 7)
+	if val, ok := s.LastInserted(); !ok || val != 7 {
+		t.Errorf("Expected 7 after replacing 4, got %d, %v", val, ok)
+	}
+
+	// Popping every element drops it
+	s.PopN(s.Len())
+	if _, ok := s.LastInserted(); ok {
+		t.Errorf("Expected no last inserted element after popping everything")
+	}
+}
+
+// TestReproducibleSampling checks that sets seeded alike make identical random choices.
+func TestReproducibleSampling(t *testing.T) {
+	build := func() snapset.SnapSet[int] {
+		s := snapset.NewWithSource[int](snapset.DefaultBucketSize, rand.NewSource(42))
+		for i := 0; i < 100; i++ {
+			s.Insert(i)
+		}
+		return s
+	}
+	a, b := build(), build()
+
+	// Sampling without replacement
+	if x, y := a.GetRandomN(10), b.GetRandomN(10); !slices.Equal(x, y) {
+		t.Errorf("Expected identical GetRandomN results, got %v and %v", x, y)
+	}
+
+	// Shuffling the internal order
+	a.Shuffle()
+	b.Shuffle()
+	if x, y := a.ToSlice(), b.ToSlice(); !slices.Equal(x, y) {
+		t.Errorf("Expected identical orders after Shuffle, got %v and %v", x, y)
+	}
+
+	// Sampling with replacement, single picks and removals
+	if x, y := a.GetRandomWithReplacement(10), b.GetRandomWithReplacement(10); !slices.Equal(x, y) {
+		t.Errorf("Expected identical GetRandomWithReplacement results, got %v and %v", x, y)
+	}
+	if x, y := a.GetRandom(), b.GetRandom(); x != y {
+		t.Errorf("Expected identical GetRandom results, got %d and %d", x, y)
+	}
+	if x, y := a.PopN(5), b.PopN(5); !slices.Equal(x, y) {
+		t.Errorf("Expected identical PopN results, got %v and %v", x, y)
+	}
+}
+
+// TestDeleteTracked checks that moves caused by swap-deletes are reported.
+func TestDeleteTracked(t *testing.T) {
+	s := snapset.New[string](snapset.DefaultBucketSize)
+	s.InsertMany("a", "b", "c")
+
+	// Deleting from the middle moves the last element
+	idx, movedElem, moved, ok := s.DeleteTracked("a")
+	if !ok || !moved || idx != 0 || movedElem != "c" {
+		t.Errorf("Expected c to move into slot 0, got %d, %q, %v, %v", idx, movedElem, moved, ok)
+	}
+	if at, _ := s.At(0); at != "c" {
+		t.Errorf("Expected c at index 0, got %s", at)
+	}
+
+	// Deleting the last element moves nothing
+	idx, _, moved, ok = s.DeleteTracked("b")
+	if !ok || moved || idx != 1 {
+		t.Errorf("Expected no move for the last element, got %d, %v, %v", idx, moved, ok)
+	}
+
+	// Absent elements are reported
+	if _, _, _, ok = s.DeleteTracked("z"); ok {
+		t.Errorf("Expected DeleteTracked to fail for an absent element")
+	}
+}
+
+// TestIterateSafe checks that every element is visited once while others are removed.
+func TestIterateSafe(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	for i := 0; i < 10; i++ {
+		s.Insert(i)
+	}
+
+	// Keep the even elements, inserting new ones along the way
+	visits := make(map[int]int)
+	s.IterateSafe(func(val int) bool {
+		visits[val]++
+		s.Insert(val + 100) // Not visited
+		return val%2 == 0
+	})
+
+	for i := 0; i < 10; i++ {
+		if visits[i] != 1 {
+			t.Errorf("Expected %d to be visited once, got %d", i, visits[i])
+		}
+		if s.Exists(i) != (i%2 == 0) {
+			t.Errorf("Expected %d to exist: %v", i, i%2 == 0)
+		}
+	}
+	if len(visits) != 10 || s.Len() != 15 {
+		t.Errorf("Expected 10 visits and 15 elements, got %d and %d", len(visits), s.Len())
+	}
+}
+
+// TestZero checks that Zero returns the zero value regardless of the contents.
+func TestZero(t *testing.T) {
+	s := snapset.NewFromSlice([]string{"a"})
+	if z := s.Zero(); z != "" {
+		t.Errorf("Expected an empty string, got %q", z)
+	}
+
+	p := snapset.NewConcurrent[*int](snapset.DefaultBucketSize)
+	if z := p.Zero(); z != nil {
+		t.Errorf("Expected nil, got %v", z)
+	}
+}
+
+// TestNegativeSize checks that negative sizes are clamped or rejected.
+func TestNegativeSize(t *testing.T) {
+	size := -5
+
+	// Constructors treat a negative size as zero
+	s := snapset.New[int](size)
+	s.Insert(1)
+	if stats := s.Stats(); stats.BucketHint != 1 || stats.Len != 1 {
+		t.Errorf("Expected bucket hint 1 and length 1, got %+v", stats)
+	}
+
+	// NewChecked rejects it
+	if _, err := snapset.NewChecked[int](size); !errors.Is(err, snapset.ErrNegativeSize) {
+		t.Errorf("Expected ErrNegativeSize, got %v", err)
+	}
+	if c, err := snapset.NewChecked[int](0); err != nil || c.Len() != 0 {
+		t.Errorf("Expected an empty set, got %v and %v", c, err)
+	}
+}
+
+// TestContentHash checks that the hash depends on the contents but not on their order.
+func TestContentHash(t *testing.T) {
+	a := snapset.NewFromSlice([]string{"x", "y", "z"})
+	b := snapset.New[string](snapset.DefaultBucketSize)
+
+	// Build the same contents in a different way
+	b.InsertMany("z", "w", "x", "y")
+	b.Delete("w")
+	b.Shuffle()
+
+	if a.ContentHash() != b.ContentHash() {
+		t.Errorf("Expected equal sets to have equal hashes")
+	}
+
+	b.Delete("x")
+	if a.ContentHash() == b.ContentHash() {
+		t.Errorf("Expected different sets to have different hashes")
+	}
+
+	// A concurrent set with the same contents hashes the same
+	c := snapset.NewConcurrent[string](snapset.DefaultBucketSize)
+	c.InsertMany("y", "x", "z")
+	if c.ContentHash() != a.ContentHash() {
+		t.Errorf("Expected a concurrent set to hash like an equal set")
+	}
+}
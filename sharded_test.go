@@ -0,0 +1,71 @@
+package snapset_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestShardedSet checks the basic operations of ShardedSet.
+func TestShardedSet(t *testing.T) {
+	s := snapset.NewSharded[int](4, snapset.DefaultBucketSize)
+
+	if _, ok := s.GetRandomOK(); ok {
+		t.Errorf("Should not be able to pick from an empty set")
+	}
+
+	for i := 0; i < 100; i++ {
+		if !s.Insert(i) {
+			t.Errorf("Element %d should be newly added", i)
+		}
+	}
+
+	if s.Insert(5) {
+		t.Errorf("Re-inserting element 5 should report false")
+	}
+
+	if s.Len() != 100 || len(s.ToSlice()) != 100 {
+		t.Errorf("Expected 100 elements, got %d", s.Len())
+	}
+
+	if !s.Delete(5) || s.Delete(5) || s.Exists(5) {
+		t.Errorf("Element 5 should be deleted exactly once")
+	}
+
+	// Every element is reachable through GetRandom
+	seen := make(map[int]bool)
+	for i := 0; i < 5000; i++ {
+		seen[s.GetRandom()] = true
+	}
+	if len(seen) != 99 || seen[5] {
+		t.Errorf("Expected all 99 remaining elements to be picked, got %d", len(seen))
+	}
+}
+
+// TestShardedSetConcurrent runs parallel inserts, deletes and reads. Run with -race.
+func TestShardedSetConcurrent(t *testing.T) {
+	s := snapset.NewSharded[int](8, snapset.DefaultBucketSize)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(base int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				val := base*500 + i
+				s.Insert(val)
+				s.Exists(val)
+				s.GetRandomOK()
+				if i%2 == 0 {
+					s.Delete(val)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if s.Len() != 2000 {
+		t.Errorf("Expected 2000 elements, got %d", s.Len())
+	}
+}
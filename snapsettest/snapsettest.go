@@ -0,0 +1,41 @@
+// Package snapsettest provides helpers for testing code that uses snapset.
+// It lives in its own package so that the snapset package does not import testing.
+package snapsettest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// AssertEqual reports a test failure through t if a and b do not contain the same elements.
+// The failure message lists the elements found only in a and only in b,
+// each sorted by their formatted value so that the output is stable across runs.
+func AssertEqual[T comparable](t testing.TB, a, b snapset.SnapSet[T]) {
+	t.Helper()
+
+	onlyA := onlyIn(a, b)
+	onlyB := onlyIn(b, a)
+	if len(onlyA) == 0 && len(onlyB) == 0 {
+		return
+	}
+
+	t.Errorf("sets differ:\n  only in a: [%s]\n  only in b: [%s]",
+		strings.Join(onlyA, " "), strings.Join(onlyB, " "))
+}
+
+// onlyIn returns the formatted elements of a that are not in b, sorted.
+func onlyIn[T comparable](a, b snapset.SnapSet[T]) []string {
+	var out []string
+	a.ForEach(func(element T) bool {
+		if !b.Exists(element) {
+			out = append(out, fmt.Sprint(element))
+		}
+		return true
+	})
+	slices.Sort(out)
+	return out
+}
@@ -0,0 +1,44 @@
+package snapsettest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/snapset"
+	"github.com/snapset/snapsettest"
+)
+
+// recorder captures failures reported through testing.TB.
+type recorder struct {
+	testing.TB
+	messages []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+// TestAssertEqual checks that only differing sets fail, with a readable diff.
+func TestAssertEqual(t *testing.T) {
+	a := snapset.NewFromSlice([]int{1, 2, 3, 10})
+	b := snapset.NewFromSlice([]int{3, 2, 1, 10})
+
+	// Equal sets pass
+	r := &recorder{TB: t}
+	snapsettest.AssertEqual(r, a, b)
+	if len(r.messages) != 0 {
+		t.Errorf("Expected no failures, got %v", r.messages)
+	}
+
+	// Differing sets list the elements on each side
+	b.Delete(2)
+	b.InsertMany(4, 5)
+	snapsettest.AssertEqual(r, a, b)
+
+	want := "sets differ:\n  only in a: [2]\n  only in b: [4 5]"
+	if len(r.messages) != 1 || r.messages[0] != want {
+		t.Errorf("Expected %q, got %q", want, r.messages)
+	}
+}
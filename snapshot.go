@@ -0,0 +1,59 @@
+package snapset
+
+// Snapshot is an immutable capture of the contents of a set at a point in time.
+// It is created by SnapSet.Snapshot and consumed by SnapSet.Restore.
+// Its contents are unexported and copied on both capture and restore,
+// so a Snapshot can never be modified after it has been created.
+type Snapshot[T comparable] struct {
+	elements []T
+}
+
+// Len returns the number of elements captured by the snapshot.
+func (snap Snapshot[T]) Len() int {
+	return len(snap.elements)
+}
+
+// Snapshot captures the current contents of the set.
+// Later mutations of the set do not affect the returned snapshot.
+func (s *Set[T]) Snapshot() Snapshot[T] {
+	return Snapshot[T]{elements: s.ToSlice()}
+}
+
+// Restore resets the set to the contents captured by snap.
+// Elements inserted since the snapshot was taken are removed and deleted elements are brought back.
+// The set reuses its existing storage, and the snapshot remains valid for further restores.
+func (s *Set[T]) Restore(snap Snapshot[T]) {
+	keep := make(map[T]struct{}, len(snap.elements))
+	for _, element := range snap.elements {
+		keep[element] = struct{}{}
+	}
+
+	// Apply only the differences, so hooks and counters observe exactly what changed
+	s.RemoveIf(func(element T) bool {
+		_, ok := keep[element]
+		return !ok
+	})
+	s.InsertMany(snap.elements...)
+}
+
+// Checkpoint saves the current contents of the set as a restore point under the given name.
+// A checkpoint with the same name is replaced. Checkpoints are kept until the set is discarded.
+func (s *Set[T]) Checkpoint(name string) {
+	if s.checkpoints == nil {
+		s.checkpoints = make(map[string]Snapshot[T])
+	}
+	s.checkpoints[name] = s.Snapshot()
+}
+
+// Rollback restores the set to the checkpoint saved under the given name.
+// The checkpoint is kept, so it can be rolled back to again later.
+// It returns false if no checkpoint with that name exists.
+func (s *Set[T]) Rollback(name string) bool {
+	snap, ok := s.checkpoints[name]
+	if !ok {
+		return false
+	}
+
+	s.Restore(snap)
+	return true
+}
@@ -0,0 +1,71 @@
+package snapset_test
+
+import (
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestSnapshotRestore checks the Snapshot and Restore methods.
+func TestSnapshotRestore(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+	s.InsertMany(1, 2, 3)
+
+	// Take a snapshot before a speculative batch
+	snap := s.Snapshot()
+	if snap.Len() != 3 {
+		t.Errorf("Expected snapshot length 3, got %d", snap.Len())
+	}
+
+	s.Delete(2)
+	s.InsertMany(4, 5)
+
+	// Roll back to the snapshot
+	s.Restore(snap)
+	assertElements(t, s, 1, 2, 3)
+
+	// The set must remain consistent after the restore
+	if _, ok := s.Delete(3); !ok {
+		t.Errorf("Failed to delete element 3 after restore")
+	}
+	assertElements(t, s, 1, 2)
+
+	// The snapshot is unaffected by mutations after the restore
+	s.Restore(snap)
+	assertElements(t, s, 1, 2, 3)
+}
+
+// TestCheckpointRollback checks the Checkpoint and Rollback methods.
+func TestCheckpointRollback(t *testing.T) {
+	s := snapset.New[string](snapset.DefaultBucketSize)
+
+	// Unknown checkpoints cannot be rolled back to
+	if s.Rollback("missing") {
+		t.Errorf("Rollback to an unknown checkpoint should fail")
+	}
+
+	// Build up several named savepoints
+	s.Insert("a")
+	s.Checkpoint("one")
+	s.Insert("b")
+	s.Checkpoint("two")
+	s.Insert("c")
+
+	if !s.Rollback("one") {
+		t.Fatalf("Rollback to checkpoint one failed")
+	}
+	assertElements(t, s, "a")
+
+	// Jump forward to a later checkpoint
+	if !s.Rollback("two") {
+		t.Fatalf("Rollback to checkpoint two failed")
+	}
+	assertElements(t, s, "a", "b")
+
+	// Overwriting a checkpoint replaces it
+	s.Delete("a")
+	s.Checkpoint("one")
+	s.Insert("z")
+	s.Rollback("one")
+	assertElements(t, s, "b")
+}
@@ -0,0 +1,35 @@
+package snapset
+
+// SetStats is a point-in-time summary of a set's size and activity,
+// suitable for exporting as metrics.
+type SetStats struct {
+	Len           int    // number of elements in the set
+	Cap           int    // capacity of the backing slice
+	BucketHint    int    // number of entries the bucket map has been sized for
+	Inserts       uint64 // elements added since creation
+	Deletes       uint64 // elements removed since creation, by any removing method
+	FailedDeletes uint64 // Delete calls for elements that did not exist
+	Reallocs      uint64 // times inserting reallocated the backing slice
+}
+
+// Stats returns the current size of the set and its cumulative insert and delete counters.
+// The counters are maintained as part of the normal insert and delete paths,
+// so collecting them costs nothing beyond this call.
+func (s *Set[T]) Stats() SetStats {
+	return SetStats{
+		Len:           len(s.list),
+		Cap:           cap(s.list),
+		BucketHint:    s.bucketHint,
+		Inserts:       s.inserts,
+		Deletes:       s.deletes,
+		FailedDeletes: s.failedDeletes,
+		Reallocs:      s.reallocs,
+	}
+}
+
+// Stats returns the current size and counters of the set under the read lock.
+func (c *ConcurrentSet[T]) Stats() SetStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Stats()
+}
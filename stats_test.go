@@ -0,0 +1,109 @@
+package snapset_test
+
+import (
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestStats checks the Stats method.
+func TestStats(t *testing.T) {
+	s := snapset.New[int](snapset.DefaultBucketSize)
+
+	s.InsertMany(1, 2, 3, 4, 5)
+	s.Insert(1) // Not counted: already present
+	s.Delete(1)
+	s.Delete(1) // Failed delete
+	s.Pop()
+	s.PopN(2)
+
+	stats := s.Stats()
+	if stats.Len != 1 || stats.Len != s.Len() {
+		t.Errorf("Expected length 1, got %d", stats.Len)
+	}
+
+	if stats.Cap < stats.Len {
+		t.Errorf("Capacity %d should not be below length %d", stats.Cap, stats.Len)
+	}
+
+	if stats.BucketHint != snapset.DefaultBucketSize {
+		t.Errorf("Expected bucket hint %d, got %d", snapset.DefaultBucketSize, stats.BucketHint)
+	}
+
+	if stats.Inserts != 5 || stats.Deletes != 4 || stats.FailedDeletes != 1 {
+		t.Errorf("Expected 5 inserts, 4 deletes and 1 failed delete, got %+v", stats)
+	}
+
+	// Clear counts every removed element
+	s.Clear()
+	if stats = s.Stats(); stats.Deletes != 5 {
+		t.Errorf("Expected 5 deletes after Clear, got %d", stats.Deletes)
+	}
+}
+
+// TestInsertTracked checks that reallocations are reported and counted.
+func TestInsertTracked(t *testing.T) {
+	s := snapset.New[int](0)
+
+	// Reserve room for two elements
+	s.Grow(2)
+	for i := 0; i < 2; i++ {
+		if _, grew := s.InsertTracked(i); grew {
+			t.Errorf("Expected no reallocation for element %d", i)
+		}
+	}
+
+	// The third element no longer fits
+	if _, grew := s.InsertTracked(2); !grew {
+		t.Errorf("Expected a reallocation for element 2")
+	}
+
+	// Existing elements never reallocate
+	if idx, grew := s.InsertTracked(0); grew || idx != 0 {
+		t.Errorf("Expected index 0 without reallocation, got %d and %v", idx, grew)
+	}
+
+	if n := s.Stats().Reallocs; n != 1 {
+		t.Errorf("Expected 1 reallocation, got %d", n)
+	}
+}
+
+// TestPresizedNoReallocs checks that the initial size presizes the backing slice.
+func TestPresizedNoReallocs(t *testing.T) {
+	s := snapset.New[int](1000)
+	for i := 0; i < 1000; i++ {
+		s.Insert(i)
+	}
+	if n := s.Stats().Reallocs; n != 0 {
+		t.Errorf("Expected 0 reallocations for a presized set, got %d", n)
+	}
+
+	// An unsized set has to grow
+	s = snapset.New[int](0)
+	for i := 0; i < 1000; i++ {
+		s.Insert(i)
+	}
+	if n := s.Stats().Reallocs; n == 0 {
+		t.Errorf("Expected reallocations for an unsized set, got none")
+	}
+}
+
+// TestNewWithGrowth checks that the growth policy decides the capacity of the backing slice.
+func TestNewWithGrowth(t *testing.T) {
+	s := snapset.NewWithGrowth[int](0, func(oldCap, needed int) int {
+		return oldCap + 4
+	})
+
+	for i := 0; i < 10; i++ {
+		s.Insert(i)
+	}
+	if stats := s.Stats(); stats.Cap != 12 || stats.Reallocs != 3 {
+		t.Errorf("Expected capacity 12 after 3 reallocations, got %d after %d", stats.Cap, stats.Reallocs)
+	}
+
+	// Batch inserts get at least the capacity they need
+	s.InsertMany(10, 11, 12, 13, 14, 15, 16, 17, 18, 19)
+	if s.Cap() != 20 || s.Len() != 20 {
+		t.Errorf("Expected capacity and length 20, got %d and %d", s.Cap(), s.Len())
+	}
+}
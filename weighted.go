@@ -0,0 +1,161 @@
+package snapset
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+)
+
+// WeightedSet is a set in which every element carries a finite, non-negative weight,
+// and GetRandom picks elements with probability proportional to their weight.
+// Like Set, it stores elements in a slice indexed by a map and removes them by swapping
+// with the last element. Cumulative weights are kept in a Fenwick tree aligned with the slice,
+// so insertion, deletion and weighted selection all run in O(log n).
+// Note: WeightedSet is not safe for concurrent use.
+type WeightedSet[T comparable] struct {
+	bucket map[T]int  // maps elements to their indices in the list
+	list   []T        // stores the elements
+	weight []float64  // weight of each element, aligned with list
+	tree   []float64  // Fenwick tree of weights; tree[i] covers the weights (i+1-lowbit(i+1), i]
+	rand   *rand.Rand // random number generator for GetRandom
+}
+
+// NewWeighted creates and returns a new, empty WeightedSet with the specified initial size.
+func NewWeighted[T comparable](size int) *WeightedSet[T] {
+	return &WeightedSet[T]{
+		bucket: make(map[T]int, max(size, 0)),
+		rand:   rand.New(newSource()),
+	}
+}
+
+// Insert adds the element with the given weight and returns its index.
+// If the element already exists, its weight is updated in place and its existing index is returned.
+// It panics if weight is negative, NaN or positive infinity.
+func (w *WeightedSet[T]) Insert(data T, weight float64) int {
+	if !(weight >= 0) || math.IsInf(weight, 1) {
+		panic("snapset: invalid weight")
+	}
+
+	if idx, ok := w.bucket[data]; ok {
+		w.add(idx, weight-w.weight[idx])
+		w.weight[idx] = weight
+		return idx
+	}
+
+	// A new tree node covers its own weight plus the preceding lowbit-1 weights
+	idx := len(w.list)
+	pos := idx + 1
+	node := weight + w.prefix(idx) - w.prefix(pos-lowbit(pos))
+
+	w.list = append(w.list, data)
+	w.weight = append(w.weight, weight)
+	w.tree = append(w.tree, node)
+	w.bucket[data] = idx
+	return idx
+}
+
+// Delete removes the specified element from the set.
+// It swaps the element with the last one, so the last element takes over its index.
+// It returns the index of the deleted element and true, or 0 and false if the element does not exist.
+func (w *WeightedSet[T]) Delete(element T) (int, bool) {
+	idx, ok := w.bucket[element]
+	if !ok {
+		return 0, false // Element does not exist
+	}
+
+	lastIdx := len(w.list) - 1
+	lastElement := w.list[lastIdx]
+
+	// Move the last element's weight into the deleted slot, then drop the last slot
+	w.add(idx, w.weight[lastIdx]-w.weight[idx])
+	w.list[idx] = lastElement
+	w.weight[idx] = w.weight[lastIdx]
+	w.bucket[lastElement] = idx
+
+	w.list = w.list[:lastIdx]
+	w.weight = w.weight[:lastIdx]
+	w.tree = w.tree[:lastIdx] // No remaining node covers the last position
+
+	delete(w.bucket, element)
+	return idx, true
+}
+
+// Exists checks whether the specified element exists in the set.
+func (w *WeightedSet[T]) Exists(element T) bool {
+	_, ok := w.bucket[element]
+	return ok
+}
+
+// Weight returns the weight of the specified element and true,
+// or 0 and false if the element does not exist.
+func (w *WeightedSet[T]) Weight(element T) (float64, bool) {
+	idx, ok := w.bucket[element]
+	if !ok {
+		return 0, false
+	}
+	return w.weight[idx], true
+}
+
+// TotalWeight returns the sum of the weights of all elements in the set.
+func (w *WeightedSet[T]) TotalWeight() float64 {
+	return w.prefix(len(w.list))
+}
+
+// Len returns the number of elements in the set.
+func (w *WeightedSet[T]) Len() int {
+	return len(w.list)
+}
+
+// GetRandom returns a random element, chosen with probability proportional to its weight.
+// It panics if the set is empty or all weights are zero.
+func (w *WeightedSet[T]) GetRandom() T {
+	element, ok := w.GetRandomOK()
+	if !ok {
+		panic("snapset: GetRandom on empty weighted set")
+	}
+	return element
+}
+
+// GetRandomOK returns a random element, chosen with probability proportional to its weight, and true.
+// If the set is empty or all weights are zero, it returns the zero value of T and false.
+func (w *WeightedSet[T]) GetRandomOK() (T, bool) {
+	total := w.TotalWeight()
+	if total <= 0 {
+		var zero T
+		return zero, false
+	}
+
+	// Descend the Fenwick tree to the first index whose cumulative weight exceeds the target
+	target := w.rand.Float64() * total
+	pos := 0
+	for step := 1 << (bits.Len(uint(len(w.tree))) - 1); step > 0; step >>= 1 {
+		if next := pos + step; next <= len(w.tree) && w.tree[next-1] <= target {
+			pos = next
+			target -= w.tree[next-1]
+		}
+	}
+
+	// Guard against floating-point rounding pushing past the last element
+	return w.list[min(pos, len(w.list)-1)], true
+}
+
+// add adds delta to the weight at idx in the Fenwick tree.
+func (w *WeightedSet[T]) add(idx int, delta float64) {
+	for pos := idx + 1; pos <= len(w.tree); pos += lowbit(pos) {
+		w.tree[pos-1] += delta
+	}
+}
+
+// prefix returns the sum of the first n weights.
+func (w *WeightedSet[T]) prefix(n int) float64 {
+	sum := 0.0
+	for pos := n; pos > 0; pos -= lowbit(pos) {
+		sum += w.tree[pos-1]
+	}
+	return sum
+}
+
+// lowbit returns the lowest set bit of pos.
+func lowbit(pos int) int {
+	return pos & -pos
+}
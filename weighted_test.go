@@ -0,0 +1,84 @@
+package snapset_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/snapset"
+)
+
+// TestWeightedInsertDelete checks the bookkeeping of WeightedSet.
+func TestWeightedInsertDelete(t *testing.T) {
+	w := snapset.NewWeighted[string](snapset.DefaultBucketSize)
+
+	// An empty set has nothing to pick
+	if _, ok := w.GetRandomOK(); ok {
+		t.Errorf("Should not be able to pick from an empty set")
+	}
+
+	w.Insert("a", 1)
+	w.Insert("b", 2)
+	w.Insert("c", 3)
+	if w.Len() != 3 || w.TotalWeight() != 6 {
+		t.Errorf("Expected 3 elements with total weight 6, got %d and %f", w.Len(), w.TotalWeight())
+	}
+
+	// Re-inserting updates the weight in place
+	if idx := w.Insert("a", 4); idx != 0 {
+		t.Errorf("Expected index 0 for existing element, got %d", idx)
+	}
+	if weight, _ := w.Weight("a"); weight != 4 {
+		t.Errorf("Expected weight 4, got %f", weight)
+	}
+
+	// Delete moves the last element into the freed slot
+	if idx, ok := w.Delete("a"); !ok || idx != 0 {
+		t.Errorf("Expected (0, true), got (%d, %t)", idx, ok)
+	}
+	if w.Exists("a") || w.TotalWeight() != 5 {
+		t.Errorf("Expected total weight 5 after deletion, got %f", w.TotalWeight())
+	}
+	if weight, ok := w.Weight("c"); !ok || weight != 3 {
+		t.Errorf("Expected element c to keep weight 3, got %f", weight)
+	}
+}
+
+// TestWeightedGetRandom checks that selection is proportional to weight.
+func TestWeightedGetRandom(t *testing.T) {
+	w := snapset.NewWeighted[string](snapset.DefaultBucketSize)
+	w.Insert("light", 1)
+	w.Insert("heavy", 3)
+	w.Insert("never", 0)
+	w.Insert("gone", 10)
+	w.Delete("gone")
+
+	const samples = 40000
+	counts := make(map[string]int)
+	for i := 0; i < samples; i++ {
+		counts[w.GetRandom()]++
+	}
+
+	if counts["never"] != 0 || counts["gone"] != 0 {
+		t.Errorf("Zero-weight and deleted elements should never be picked: %v", counts)
+	}
+
+	ratio := float64(counts["heavy"]) / samples
+	if math.Abs(ratio-0.75) > 0.02 {
+		t.Errorf("Expected heavy element picked ~75%% of the time, got %.3f", ratio)
+	}
+}
+
+// TestWeightedInvalidWeight checks that Insert rejects negative, NaN and infinite weights.
+func TestWeightedInvalidWeight(t *testing.T) {
+	for _, weight := range []float64{-1, math.NaN(), math.Inf(1)} {
+		func() {
+			w := snapset.NewWeighted[string](snapset.DefaultBucketSize)
+			defer func() {
+				if r := recover(); r != "snapset: invalid weight" {
+					t.Errorf("Expected a panic for weight %v, got %v", weight, r)
+				}
+			}()
+			w.Insert("a", weight)
+		}()
+	}
+}